@@ -0,0 +1,78 @@
+package datasources
+
+import (
+	"context"
+
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/client/types"
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/helpers"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const availabilityZoneStateAvailable = "available"
+
+// DataSourceAceCloudAvailabilityZone looks up an availability zone by name.
+// Without a name, the region must have exactly one available zone.
+func DataSourceAceCloudAvailabilityZone() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceAceCloudAvailabilityZoneRead,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Name of the availability zone",
+			},
+			"state": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Current state of the availability zone",
+			},
+			"region":     helpers.DataSourceRegionSchema(),
+			"project_id": helpers.DataSourceProjectIDSchema(),
+		},
+	}
+}
+
+func dataSourceAceCloudAvailabilityZoneRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := helpers.ScopedClient(d, meta)
+
+	all, err := c.ListAvailabilityZones(ctx, nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var zones []types.AvailabilityZone
+	if v, ok := d.GetOk("name"); ok {
+		zones = filterAvailabilityZones(all, func(z types.AvailabilityZone) bool { return z.Name == v.(string) })
+	} else {
+		zones = filterAvailabilityZones(all, func(z types.AvailabilityZone) bool { return z.State == availabilityZoneStateAvailable })
+	}
+
+	if len(zones) == 0 {
+		return diag.Errorf("no availability zone matches the given criteria")
+	}
+	if len(zones) > 1 {
+		return diag.Errorf("%d availability zones are available; set name to select one", len(zones))
+	}
+
+	z := zones[0]
+	d.SetId(z.Name)
+	_ = d.Set("name", z.Name)
+	_ = d.Set("state", z.State)
+
+	helpers.SetScope(d, c)
+
+	return nil
+}
+
+func filterAvailabilityZones(zones []types.AvailabilityZone, match func(types.AvailabilityZone) bool) []types.AvailabilityZone {
+	out := make([]types.AvailabilityZone, 0, len(zones))
+	for _, z := range zones {
+		if match(z) {
+			out = append(out, z)
+		}
+	}
+	return out
+}
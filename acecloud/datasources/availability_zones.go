@@ -0,0 +1,59 @@
+package datasources
+
+import (
+	"context"
+
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/client/types"
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/helpers"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourceAceCloudAvailabilityZones() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceAceCloudAvailabilityZonesRead,
+
+		Schema: map[string]*schema.Schema{
+			"state": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     availabilityZoneStateAvailable,
+				Description: "Only return zones in this state. Set to an empty string to return all zones",
+			},
+			"names": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Names of the matching availability zones",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"region":     helpers.DataSourceRegionSchema(),
+			"project_id": helpers.DataSourceProjectIDSchema(),
+		},
+	}
+}
+
+func dataSourceAceCloudAvailabilityZonesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := helpers.ScopedClient(d, meta)
+
+	all, err := c.ListAvailabilityZones(ctx, nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	state := d.Get("state").(string)
+	zones := filterAvailabilityZones(all, func(z types.AvailabilityZone) bool {
+		return state == "" || z.State == state
+	})
+
+	names := make([]string, 0, len(zones))
+	for _, z := range zones {
+		names = append(names, z.Name)
+	}
+
+	d.SetId(helpers.HashStrings(names))
+	_ = d.Set("names", names)
+
+	helpers.SetScope(d, c)
+
+	return nil
+}
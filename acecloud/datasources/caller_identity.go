@@ -0,0 +1,67 @@
+package datasources
+
+import (
+	"context"
+
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/helpers"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// DataSourceAceCloudCallerIdentity returns the identity the configured API key
+// resolves to.
+func DataSourceAceCloudCallerIdentity() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceAceCloudCallerIdentityRead,
+
+		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the account the API key belongs to",
+			},
+			"client_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Tenant/client ID of the account",
+			},
+			"user_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "ID of the user the API key was issued to",
+			},
+			"email": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Email address of the user",
+			},
+			"region":     helpers.DataSourceRegionSchema(),
+			"project_id": helpers.DataSourceProjectIDSchema(),
+		},
+	}
+}
+
+func dataSourceAceCloudCallerIdentityRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := helpers.ScopedClient(d, meta)
+
+	id, err := c.GetCallerIdentity(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(id.AccountID)
+	_ = d.Set("account_id", id.AccountID)
+	_ = d.Set("client_id", id.ClientID)
+	_ = d.Set("user_id", id.UserID)
+	_ = d.Set("email", id.Email)
+
+	helpers.SetScope(d, c)
+
+	// The key's own project wins when neither the data source nor the
+	// provider names one.
+	if c.ProjectID == "" {
+		_ = d.Set("project_id", id.ProjectID)
+	}
+
+	return nil
+}
@@ -0,0 +1,30 @@
+package datasources
+
+import (
+	"testing"
+
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/client/types"
+)
+
+func TestCallerIdentityRead(t *testing.T) {
+	identity := types.CallerIdentity{AccountID: "acc-1", ClientID: 42, UserID: 7, ProjectID: "key-project", Email: "ops@example.com"}
+	c := listClient(t, identity, nil)
+
+	d, diags := read(t, DataSourceAceCloudCallerIdentity(), map[string]any{}, c)
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	if d.Id() != "acc-1" || d.Get("client_id") != 42 || d.Get("user_id") != 7 || d.Get("email") != "ops@example.com" || d.Get("project_id") != "test-project" {
+		t.Errorf("read %q: client_id %v, user_id %v, email %q, project_id %q", d.Id(), d.Get("client_id"), d.Get("user_id"), d.Get("email"), d.Get("project_id"))
+	}
+
+	// Without a project from the provider, the key's own project is used.
+	c.ProjectID = ""
+	d, diags = read(t, DataSourceAceCloudCallerIdentity(), map[string]any{}, c)
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	if d.Get("project_id") != "key-project" {
+		t.Errorf("project_id = %q, want the key's project", d.Get("project_id"))
+	}
+}
@@ -0,0 +1,58 @@
+package datasources
+
+import (
+	"regexp"
+
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/helpers"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// idNameTagsFilter matches objects by ID, exact name, name regex and tags.
+// Zero-valued fields match everything.
+type idNameTagsFilter struct {
+	ID        string
+	Name      string
+	NameRegex *regexp.Regexp
+	Tags      []string
+}
+
+// idNameTagsFilterFromResourceData reads whichever of the id, name,
+// name_regex and tags arguments are present in the data source schema.
+func idNameTagsFilterFromResourceData(d *schema.ResourceData) idNameTagsFilter {
+	var filter idNameTagsFilter
+	if v, ok := d.GetOk("id"); ok {
+		filter.ID = v.(string)
+	}
+	if v, ok := d.GetOk("name"); ok {
+		filter.Name = v.(string)
+	}
+	if v, ok := d.GetOk("name_regex"); ok {
+		filter.NameRegex = regexp.MustCompile(v.(string))
+	}
+	if v, ok := d.GetOk("tags"); ok {
+		filter.Tags = helpers.InterfaceSliceToStringSlice(v.(*schema.Set).List())
+	}
+	return filter
+}
+
+func (f idNameTagsFilter) match(id, name string, tags []string) bool {
+	if f.ID != "" && id != f.ID {
+		return false
+	}
+	if f.Name != "" && name != f.Name {
+		return false
+	}
+	if f.NameRegex != nil && !f.NameRegex.MatchString(name) {
+		return false
+	}
+	return hasAllTags(tags, f.Tags)
+}
+
+func hasAllTags(have, want []string) bool {
+	for _, t := range want {
+		if !helpers.StringInSlice(t, have) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,132 @@
+package datasources
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/client/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestIDNameTagsFilterFromResourceData(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		r    *schema.Resource
+		raw  map[string]any
+		want idNameTagsFilter
+	}{
+		{"empty", DataSourceAceCloudNetwork(), map[string]any{}, idNameTagsFilter{}},
+		{"id", DataSourceAceCloudNetwork(), map[string]any{"id": "net-1"}, idNameTagsFilter{ID: "net-1"}},
+		{"name and tags", DataSourceAceCloudSecurityGroup(), map[string]any{"name": "web", "tags": []any{"prod"}}, idNameTagsFilter{Name: "web", Tags: []string{"prod"}}},
+		{"name_regex", DataSourceAceCloudNetworks(), map[string]any{"name_regex": "^app-"}, idNameTagsFilter{NameRegex: regexp.MustCompile("^app-")}},
+		// Arguments missing from the schema, here tags, are left unset.
+		{"schema without tags", DataSourceAceCloudVolumeTypes(), map[string]any{"name_regex": "ssd"}, idNameTagsFilter{NameRegex: regexp.MustCompile("ssd")}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := idNameTagsFilterFromResourceData(schema.TestResourceDataRaw(t, tc.r.Schema, tc.raw))
+			if got.ID != tc.want.ID || got.Name != tc.want.Name || !slices.Equal(got.Tags, tc.want.Tags) ||
+				(got.NameRegex == nil) != (tc.want.NameRegex == nil) ||
+				(got.NameRegex != nil && got.NameRegex.String() != tc.want.NameRegex.String()) {
+				t.Errorf("filter = %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestIDNameTagsFilterMatch(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		filter idNameTagsFilter
+		want   bool
+	}{
+		{"empty", idNameTagsFilter{}, true},
+		{"id", idNameTagsFilter{ID: "net-1"}, true},
+		{"other id", idNameTagsFilter{ID: "net-2"}, false},
+		{"name", idNameTagsFilter{Name: "app-private"}, true},
+		{"name is exact", idNameTagsFilter{Name: "app"}, false},
+		{"name_regex", idNameTagsFilter{NameRegex: regexp.MustCompile("^app-")}, true},
+		{"name_regex mismatch", idNameTagsFilter{NameRegex: regexp.MustCompile("^db-")}, false},
+		{"all tags", idNameTagsFilter{Tags: []string{"prod", "private"}}, true},
+		{"missing tag", idNameTagsFilter{Tags: []string{"prod", "public"}}, false},
+		{"every field", idNameTagsFilter{ID: "net-1", Name: "app-private", NameRegex: regexp.MustCompile("private$"), Tags: []string{"prod"}}, true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.filter.match("net-1", "app-private", []string{"private", "prod"}); got != tc.want {
+				t.Errorf("match = %t, want %t", got, tc.want)
+			}
+		})
+	}
+}
+
+var testNetworks = []types.Network{
+	{ID: "net-1", Name: "app-private", Tags: []string{"prod"}},
+	{ID: "net-2", Name: "app-public", Tags: []string{"prod"}, External: true},
+	{ID: "net-3", Name: "db-private", Tags: []string{"staging"}},
+}
+
+func TestNetworkRead(t *testing.T) {
+	c := listClient(t, testNetworks, nil)
+
+	d, diags := read(t, DataSourceAceCloudNetwork(), map[string]any{"name": "app-public"}, c)
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	if d.Id() != "net-2" || d.Get("external") != true {
+		t.Errorf("read network %q, external %v", d.Id(), d.Get("external"))
+	}
+
+	_, diags = read(t, DataSourceAceCloudNetwork(), map[string]any{"tags": []any{"prod"}}, c)
+	wantError(t, diags, "2 networks match the given criteria; use more specific criteria")
+
+	_, diags = read(t, DataSourceAceCloudNetwork(), map[string]any{"id": "net-4"}, c)
+	wantError(t, diags, "no network matches the given criteria")
+
+	d, diags = read(t, DataSourceAceCloudNetworks(), map[string]any{"name_regex": "-private$"}, c)
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	if got := d.Get("ids").([]any); len(got) != 2 || got[0] != "net-1" || got[1] != "net-3" {
+		t.Errorf("ids = %v", got)
+	}
+}
+
+func TestAvailabilityZoneRead(t *testing.T) {
+	zones := []types.AvailabilityZone{
+		{Name: "zone-a", State: availabilityZoneStateAvailable},
+		{Name: "zone-b", State: "unavailable"},
+	}
+
+	// Without a name, the only available zone is picked.
+	d, diags := read(t, DataSourceAceCloudAvailabilityZone(), map[string]any{}, listClient(t, zones, nil))
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	if d.Id() != "zone-a" {
+		t.Errorf("read zone %q, want zone-a", d.Id())
+	}
+
+	d, diags = read(t, DataSourceAceCloudAvailabilityZone(), map[string]any{"name": "zone-b"}, listClient(t, zones, nil))
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	if d.Get("state") != "unavailable" {
+		t.Errorf("state = %v", d.Get("state"))
+	}
+
+	zones[1].State = availabilityZoneStateAvailable
+	_, diags = read(t, DataSourceAceCloudAvailabilityZone(), map[string]any{}, listClient(t, zones, nil))
+	wantError(t, diags, "2 availability zones are available; set name to select one")
+}
+
+func TestFilterFunctionsKeepOrder(t *testing.T) {
+	groups := []types.SecurityGroup{{ID: "sg-2", Name: "web"}, {ID: "sg-1", Name: "web"}, {ID: "sg-3", Name: "db"}}
+	var ids []string
+	for _, sg := range filterSecurityGroups(groups, idNameTagsFilter{Name: "web"}) {
+		ids = append(ids, sg.ID)
+	}
+	if got := strings.Join(ids, " "); got != "sg-2 sg-1" {
+		t.Errorf("filterSecurityGroups = %q, want the API order", got)
+	}
+}
@@ -0,0 +1,194 @@
+package datasources
+
+import (
+	"context"
+	"sort"
+
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/client/types"
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/helpers"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// flavorSelectors are the arguments that select a flavor. Without any of them
+// every flavor matches and the smallest one would be picked silently.
+var flavorSelectors = []string{"name", "min_vcpus", "min_ram", "min_disk", "min_gpus", "gpu_model"}
+
+// DataSourceAceCloudFlavor looks up a single flavor by name or by minimum
+// resource constraints. When several flavors match, the smallest is returned.
+func DataSourceAceCloudFlavor() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceAceCloudFlavorRead,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Exact name of the flavor",
+				AtLeastOneOf: flavorSelectors,
+			},
+			"min_vcpus": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Minimum number of vCPUs",
+				ValidateFunc: validation.IntAtLeast(0),
+				AtLeastOneOf: flavorSelectors,
+			},
+			"min_ram": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Minimum amount of RAM in MB",
+				ValidateFunc: validation.IntAtLeast(0),
+				AtLeastOneOf: flavorSelectors,
+			},
+			"min_disk": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Minimum root disk size in GB",
+				ValidateFunc: validation.IntAtLeast(0),
+				AtLeastOneOf: flavorSelectors,
+			},
+			"min_gpus": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Minimum number of GPUs",
+				ValidateFunc: validation.IntAtLeast(0),
+				AtLeastOneOf: flavorSelectors,
+			},
+			"gpu_model": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "GPU model the flavor must provide",
+				AtLeastOneOf: flavorSelectors,
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Description of the flavor",
+			},
+			"vcpus": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of vCPUs",
+			},
+			"ram": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Amount of RAM in MB",
+			},
+			"disk": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Root disk size in GB",
+			},
+			"gpus": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of GPUs",
+			},
+			"region":     helpers.DataSourceRegionSchema(),
+			"project_id": helpers.DataSourceProjectIDSchema(),
+		},
+	}
+}
+
+func dataSourceAceCloudFlavorRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := helpers.ScopedClient(d, meta)
+
+	all, err := c.ListFlavors(ctx, nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	filter := flavorFilter{
+		MinVCPUs: d.Get("min_vcpus").(int),
+		MinRAM:   d.Get("min_ram").(int),
+		MinDisk:  d.Get("min_disk").(int),
+		MinGPUs:  d.Get("min_gpus").(int),
+		GPUModel: d.Get("gpu_model").(string),
+	}
+	if v, ok := d.GetOk("name"); ok {
+		name := v.(string)
+		filter.Name = func(n string) bool { return n == name }
+	}
+
+	flavors := filterFlavors(all, filter)
+	if len(flavors) == 0 {
+		return diag.Errorf("no flavor matches the given criteria")
+	}
+
+	f := flavors[0]
+	d.SetId(f.ID)
+	_ = d.Set("name", f.Name)
+	_ = d.Set("description", f.Description)
+	_ = d.Set("vcpus", f.VCPUs)
+	_ = d.Set("ram", f.RAM)
+	_ = d.Set("disk", f.Disk)
+	_ = d.Set("gpus", f.GPUs)
+	_ = d.Set("gpu_model", f.GPUModel)
+
+	helpers.SetScope(d, c)
+
+	return nil
+}
+
+type flavorFilter struct {
+	Name     func(string) bool
+	MinVCPUs int
+	MinRAM   int
+	MinDisk  int
+	MinGPUs  int
+	GPUModel string
+}
+
+// filterFlavors returns the flavors matching filter ordered from smallest to
+// largest by GPUs, vCPUs, RAM and disk.
+func filterFlavors(flavors []types.Flavor, filter flavorFilter) []types.Flavor {
+	out := make([]types.Flavor, 0, len(flavors))
+	for _, f := range flavors {
+		if filter.Name != nil && !filter.Name(f.Name) {
+			continue
+		}
+		if f.VCPUs < filter.MinVCPUs || f.RAM < filter.MinRAM || f.Disk < filter.MinDisk || f.GPUs < filter.MinGPUs {
+			continue
+		}
+		if filter.GPUModel != "" && f.GPUModel != filter.GPUModel {
+			continue
+		}
+		out = append(out, f)
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		a, b := out[i], out[j]
+		if a.GPUs != b.GPUs {
+			return a.GPUs < b.GPUs
+		}
+		if a.VCPUs != b.VCPUs {
+			return a.VCPUs < b.VCPUs
+		}
+		if a.RAM != b.RAM {
+			return a.RAM < b.RAM
+		}
+		if a.Disk != b.Disk {
+			return a.Disk < b.Disk
+		}
+		return a.Name < b.Name
+	})
+	return out
+}
+
+func flattenFlavor(f types.Flavor) map[string]interface{} {
+	return map[string]interface{}{
+		"id":          f.ID,
+		"name":        f.Name,
+		"description": f.Description,
+		"vcpus":       f.VCPUs,
+		"ram":         f.RAM,
+		"disk":        f.Disk,
+		"gpus":        f.GPUs,
+		"gpu_model":   f.GPUModel,
+	}
+}
@@ -0,0 +1,132 @@
+package datasources
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/client"
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/client/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// listClient returns a client whose list calls all return items as a single
+// page. The requests it sends are appended to requests.
+func listClient(t *testing.T, items any, requests *[]*http.Request) *client.AceCloudClient {
+	t.Helper()
+	body, err := json.Marshal(map[string]any{"data": items})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := client.NewAceCloudClient("https://api.acecloud.test", "test-api-key", "test-region", "test-project")
+	c.HTTPClient = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if requests != nil {
+			*requests = append(*requests, req)
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(string(body))),
+			Request:    req,
+		}, nil
+	})}
+	return c
+}
+
+// read runs the data source's read with raw as its configuration.
+func read(t *testing.T, r *schema.Resource, raw map[string]any, c *client.AceCloudClient) (*schema.ResourceData, diag.Diagnostics) {
+	t.Helper()
+	d := schema.TestResourceDataRaw(t, r.Schema, raw)
+	return d, r.ReadContext(context.Background(), d, c)
+}
+
+// wantError fails unless diags hold exactly the error summary.
+func wantError(t *testing.T, diags diag.Diagnostics, summary string) {
+	t.Helper()
+	if !diags.HasError() || diags[0].Summary != summary {
+		t.Errorf("read returned %v, want error %q", diags, summary)
+	}
+}
+
+var testFlavors = []types.Flavor{
+	{ID: "f-large", Name: "C.8x16", VCPUs: 8, RAM: 16384, Disk: 80},
+	{ID: "f-small", Name: "C.2x4", VCPUs: 2, RAM: 4096, Disk: 40},
+	{ID: "f-gpu", Name: "G.8x32.A100", VCPUs: 8, RAM: 32768, Disk: 100, GPUs: 1, GPUModel: "A100"},
+	{ID: "f-medium-disk", Name: "C.4x8.d", VCPUs: 4, RAM: 8192, Disk: 100},
+	{ID: "f-medium", Name: "C.4x8", VCPUs: 4, RAM: 8192, Disk: 40},
+	{ID: "f-medium-alias", Name: "C.4x8.b", VCPUs: 4, RAM: 8192, Disk: 40},
+}
+
+func flavorIDs(flavors []types.Flavor) []string {
+	var ids []string
+	for _, f := range flavors {
+		ids = append(ids, f.ID)
+	}
+	return ids
+}
+
+func TestFilterFlavors(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		filter flavorFilter
+		want   string
+	}{
+		// Ties on GPUs, vCPUs and RAM are broken by disk, then by name.
+		{"no constraints", flavorFilter{}, "f-small f-medium f-medium-alias f-medium-disk f-large f-gpu"},
+		{"min_vcpus", flavorFilter{MinVCPUs: 3}, "f-medium f-medium-alias f-medium-disk f-large f-gpu"},
+		{"min_ram", flavorFilter{MinRAM: 16384}, "f-large f-gpu"},
+		{"min_disk", flavorFilter{MinDisk: 80}, "f-medium-disk f-large f-gpu"},
+		{"min_gpus", flavorFilter{MinGPUs: 1}, "f-gpu"},
+		{"gpu_model", flavorFilter{GPUModel: "H100"}, ""},
+		{"name", flavorFilter{Name: func(n string) bool { return n == "C.4x8" }}, "f-medium"},
+		{"all constraints", flavorFilter{MinVCPUs: 4, MinRAM: 8192, MinDisk: 50}, "f-medium-disk f-large f-gpu"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := strings.Join(flavorIDs(filterFlavors(testFlavors, tc.filter)), " "); got != tc.want {
+				t.Errorf("filterFlavors = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFlavorRead(t *testing.T) {
+	c := listClient(t, testFlavors, nil)
+
+	// The smallest flavor meeting every constraint is picked.
+	d, diags := read(t, DataSourceAceCloudFlavor(), map[string]any{"min_vcpus": 4, "min_disk": 50}, c)
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	if d.Id() != "f-medium-disk" || d.Get("name") != "C.4x8.d" || d.Get("vcpus") != 4 || d.Get("region") != "test-region" {
+		t.Errorf("read flavor %q named %q with %v vCPUs in %q", d.Id(), d.Get("name"), d.Get("vcpus"), d.Get("region"))
+	}
+
+	_, diags = read(t, DataSourceAceCloudFlavor(), map[string]any{"min_gpus": 2}, c)
+	wantError(t, diags, "no flavor matches the given criteria")
+}
+
+func TestFlavorRequiresSelector(t *testing.T) {
+	r := DataSourceAceCloudFlavor()
+	if diags := r.Validate(terraform.NewResourceConfigRaw(map[string]any{"region": "test-region"})); !diags.HasError() {
+		t.Error("configuration without name or constraints is valid")
+	}
+	for _, name := range flavorSelectors {
+		var value any = 1
+		if r.Schema[name].Type == schema.TypeString {
+			value = "x"
+		}
+		if diags := r.Validate(terraform.NewResourceConfigRaw(map[string]any{name: value})); diags.HasError() {
+			t.Errorf("configuration with only %s is invalid: %v", name, diags)
+		}
+	}
+}
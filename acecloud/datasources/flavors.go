@@ -0,0 +1,122 @@
+package datasources
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/helpers"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// DataSourceAceCloudFlavors lists the flavors matching the given constraints,
+// ordered from smallest to largest.
+func DataSourceAceCloudFlavors() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceAceCloudFlavorsRead,
+
+		Schema: map[string]*schema.Schema{
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Regular expression the flavor name must match",
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			"min_vcpus": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Minimum number of vCPUs",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"min_ram": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Minimum amount of RAM in MB",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"min_disk": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Minimum root disk size in GB",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"min_gpus": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Minimum number of GPUs",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"gpu_model": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "GPU model the flavors must provide",
+			},
+			"ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "IDs of the matching flavors",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"flavors": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Matching flavors",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id":          {Type: schema.TypeString, Computed: true},
+						"name":        {Type: schema.TypeString, Computed: true},
+						"description": {Type: schema.TypeString, Computed: true},
+						"vcpus":       {Type: schema.TypeInt, Computed: true},
+						"ram":         {Type: schema.TypeInt, Computed: true},
+						"disk":        {Type: schema.TypeInt, Computed: true},
+						"gpus":        {Type: schema.TypeInt, Computed: true},
+						"gpu_model":   {Type: schema.TypeString, Computed: true},
+					},
+				},
+			},
+			"region":     helpers.DataSourceRegionSchema(),
+			"project_id": helpers.DataSourceProjectIDSchema(),
+		},
+	}
+}
+
+func dataSourceAceCloudFlavorsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := helpers.ScopedClient(d, meta)
+
+	all, err := c.ListFlavors(ctx, nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	filter := flavorFilter{
+		MinVCPUs: d.Get("min_vcpus").(int),
+		MinRAM:   d.Get("min_ram").(int),
+		MinDisk:  d.Get("min_disk").(int),
+		MinGPUs:  d.Get("min_gpus").(int),
+		GPUModel: d.Get("gpu_model").(string),
+	}
+	if v, ok := d.GetOk("name_regex"); ok {
+		re := regexp.MustCompile(v.(string))
+		filter.Name = re.MatchString
+	}
+
+	flavors := filterFlavors(all, filter)
+
+	ids := make([]string, 0, len(flavors))
+	out := make([]interface{}, 0, len(flavors))
+	for _, f := range flavors {
+		ids = append(ids, f.ID)
+		out = append(out, flattenFlavor(f))
+	}
+
+	d.SetId(helpers.HashStrings(ids))
+	_ = d.Set("ids", ids)
+	if err := d.Set("flavors", out); err != nil {
+		return diag.FromErr(err)
+	}
+
+	helpers.SetScope(d, c)
+
+	return nil
+}
@@ -0,0 +1,220 @@
+package datasources
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/client/types"
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/helpers"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var imageVisibilities = []string{"public", "private", "shared", "community"}
+
+// DataSourceAceCloudImage looks up a single image. If several images match,
+// most_recent must be set to pick the newest one.
+func DataSourceAceCloudImage() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceAceCloudImageRead,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				Description:   "Exact name of the image",
+				ConflictsWith: []string{"name_regex"},
+			},
+			"name_regex": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "Regular expression the image name must match",
+				ValidateFunc:  validation.StringIsValidRegExp,
+				ConflictsWith: []string{"name"},
+			},
+			"os_distro": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Operating system distribution, e.g. ubuntu",
+			},
+			"os_version": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Operating system version, e.g. 22.04",
+			},
+			"visibility": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Image visibility: public, private, shared or community",
+				ValidateFunc: validation.StringInSlice(imageVisibilities, false),
+			},
+			"tags": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Computed:    true,
+				Description: "Tags the image must carry. All listed tags must be present",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"most_recent": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Return the most recently created image when several images match",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Current status of the image",
+			},
+			"min_disk": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Minimum root disk size in GB required to boot the image",
+			},
+			"min_ram": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Minimum amount of RAM in MB required to boot the image",
+			},
+			"size": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Size of the image in bytes",
+			},
+			"created_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Creation timestamp of the image",
+			},
+			"region":     helpers.DataSourceRegionSchema(),
+			"project_id": helpers.DataSourceProjectIDSchema(),
+		},
+	}
+}
+
+func dataSourceAceCloudImageRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := helpers.ScopedClient(d, meta)
+
+	all, err := c.ListImages(ctx, nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	filter := imageFilterFromResourceData(d)
+	if v, ok := d.GetOk("name"); ok {
+		name := v.(string)
+		filter.Name = func(n string) bool { return n == name }
+	}
+
+	images := filterImages(all, filter)
+	if len(images) == 0 {
+		return diag.Errorf("no image matches the given criteria")
+	}
+	if len(images) > 1 && !d.Get("most_recent").(bool) {
+		return diag.Errorf("%d images match the given criteria; use more specific criteria or set most_recent = true", len(images))
+	}
+
+	img := images[0]
+	d.SetId(img.ID)
+	_ = d.Set("name", img.Name)
+	_ = d.Set("os_distro", img.OSDistro)
+	_ = d.Set("os_version", img.OSVersion)
+	_ = d.Set("visibility", img.Visibility)
+	_ = d.Set("tags", img.Tags)
+	_ = d.Set("status", img.Status)
+	_ = d.Set("min_disk", img.MinDisk)
+	_ = d.Set("min_ram", img.MinRAM)
+	_ = d.Set("size", img.Size)
+	_ = d.Set("created_at", img.CreatedAt)
+
+	helpers.SetScope(d, c)
+
+	return nil
+}
+
+type imageFilter struct {
+	Name       func(string) bool
+	OSDistro   string
+	OSVersion  string
+	Visibility string
+	Tags       []string
+}
+
+// imageFilterFromResourceData reads the filter arguments shared by the
+// singular and plural image data sources. The name filter is set by callers.
+func imageFilterFromResourceData(d *schema.ResourceData) imageFilter {
+	filter := imageFilter{
+		OSDistro:   d.Get("os_distro").(string),
+		OSVersion:  d.Get("os_version").(string),
+		Visibility: d.Get("visibility").(string),
+	}
+	if v, ok := d.GetOk("name_regex"); ok {
+		re := regexp.MustCompile(v.(string))
+		filter.Name = re.MatchString
+	}
+	if v, ok := d.GetOk("tags"); ok {
+		filter.Tags = helpers.InterfaceSliceToStringSlice(v.(*schema.Set).List())
+	}
+	return filter
+}
+
+// filterImages returns the images matching filter, newest first.
+func filterImages(images []types.Image, filter imageFilter) []types.Image {
+	out := make([]types.Image, 0, len(images))
+	for _, img := range images {
+		if filter.Name != nil && !filter.Name(img.Name) {
+			continue
+		}
+		if filter.OSDistro != "" && img.OSDistro != filter.OSDistro {
+			continue
+		}
+		if filter.OSVersion != "" && img.OSVersion != filter.OSVersion {
+			continue
+		}
+		if filter.Visibility != "" && img.Visibility != filter.Visibility {
+			continue
+		}
+		if !hasAllTags(img.Tags, filter.Tags) {
+			continue
+		}
+		out = append(out, img)
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		return parseTimestamp(out[i].CreatedAt).After(parseTimestamp(out[j].CreatedAt))
+	})
+	return out
+}
+
+func flattenImage(img types.Image) map[string]interface{} {
+	return map[string]interface{}{
+		"id":         img.ID,
+		"name":       img.Name,
+		"status":     img.Status,
+		"os_distro":  img.OSDistro,
+		"os_version": img.OSVersion,
+		"visibility": img.Visibility,
+		"tags":       img.Tags,
+		"min_disk":   img.MinDisk,
+		"min_ram":    img.MinRAM,
+		"size":       img.Size,
+		"created_at": img.CreatedAt,
+	}
+}
+
+// parseTimestamp parses an RFC 3339 API timestamp. Unparseable values sort
+// as the zero time.
+func parseTimestamp(s string) time.Time {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
@@ -0,0 +1,91 @@
+package datasources
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/client/types"
+)
+
+var testImages = []types.Image{
+	{ID: "i-2204-old", Name: "ubuntu-22.04-20250101", OSDistro: "ubuntu", OSVersion: "22.04", Visibility: "public", CreatedAt: "2025-01-01T00:00:00Z"},
+	{ID: "i-2404", Name: "ubuntu-24.04-20250601", OSDistro: "ubuntu", OSVersion: "24.04", Visibility: "public", Tags: []string{"lts"}, CreatedAt: "2025-06-01T00:00:00Z"},
+	{ID: "i-unknown-date", Name: "ubuntu-22.04-custom", OSDistro: "ubuntu", OSVersion: "22.04", Visibility: "private", CreatedAt: "yesterday"},
+	{ID: "i-2204-new", Name: "ubuntu-22.04-20250301", OSDistro: "ubuntu", OSVersion: "22.04", Visibility: "public", Tags: []string{"lts", "gpu"}, CreatedAt: "2025-03-01T00:00:00+05:30"},
+	{ID: "i-rocky", Name: "rocky-9", OSDistro: "rocky", OSVersion: "9", Visibility: "public", CreatedAt: "2025-05-01T00:00:00Z"},
+}
+
+func imageIDs(images []types.Image) string {
+	var ids []string
+	for _, img := range images {
+		ids = append(ids, img.ID)
+	}
+	return strings.Join(ids, " ")
+}
+
+func TestFilterImages(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		filter imageFilter
+		want   string
+	}{
+		// Newest first; an unparseable created_at sorts as the oldest.
+		{"no filter", imageFilter{}, "i-2404 i-rocky i-2204-new i-2204-old i-unknown-date"},
+		{"os", imageFilter{OSDistro: "ubuntu", OSVersion: "22.04"}, "i-2204-new i-2204-old i-unknown-date"},
+		{"visibility", imageFilter{OSDistro: "ubuntu", Visibility: "private"}, "i-unknown-date"},
+		{"tags", imageFilter{Tags: []string{"lts", "gpu"}}, "i-2204-new"},
+		{"name", imageFilter{Name: func(n string) bool { return strings.HasPrefix(n, "rocky") }}, "i-rocky"},
+		{"no match", imageFilter{OSDistro: "debian"}, ""},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := imageIDs(filterImages(testImages, tc.filter)); got != tc.want {
+				t.Errorf("filterImages = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestImageReadMostRecent(t *testing.T) {
+	c := listClient(t, testImages, nil)
+	ubuntu2204 := map[string]any{"name_regex": "^ubuntu-22\\.04-", "visibility": "public"}
+
+	// Several images match, so the lookup is ambiguous without most_recent.
+	_, diags := read(t, DataSourceAceCloudImage(), ubuntu2204, c)
+	wantError(t, diags, "2 images match the given criteria; use more specific criteria or set most_recent = true")
+
+	ubuntu2204["most_recent"] = true
+	d, diags := read(t, DataSourceAceCloudImage(), ubuntu2204, c)
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	if d.Id() != "i-2204-new" || d.Get("name") != "ubuntu-22.04-20250301" || d.Get("created_at") != "2025-03-01T00:00:00+05:30" {
+		t.Errorf("read image %q named %q created at %q", d.Id(), d.Get("name"), d.Get("created_at"))
+	}
+
+	// A single match needs no most_recent.
+	d, diags = read(t, DataSourceAceCloudImage(), map[string]any{"name": "rocky-9"}, c)
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	if d.Id() != "i-rocky" {
+		t.Errorf("read image %q, want i-rocky", d.Id())
+	}
+
+	_, diags = read(t, DataSourceAceCloudImage(), map[string]any{"os_distro": "debian", "most_recent": true}, c)
+	wantError(t, diags, "no image matches the given criteria")
+}
+
+func TestImagesRead(t *testing.T) {
+	c := listClient(t, testImages, nil)
+
+	d, diags := read(t, DataSourceAceCloudImages(), map[string]any{"os_distro": "ubuntu", "tags": []any{"lts"}}, c)
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	if got := d.Get("ids").([]any); len(got) != 2 || got[0] != "i-2404" || got[1] != "i-2204-new" {
+		t.Errorf("ids = %v, want newest first", got)
+	}
+	if d.Get("images.0.os_version") != "24.04" {
+		t.Errorf("images.0 = %v", d.Get("images.0"))
+	}
+}
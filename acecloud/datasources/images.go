@@ -0,0 +1,109 @@
+package datasources
+
+import (
+	"context"
+
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/helpers"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// DataSourceAceCloudImages lists the images matching the given criteria,
+// newest first.
+func DataSourceAceCloudImages() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceAceCloudImagesRead,
+
+		Schema: map[string]*schema.Schema{
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Regular expression the image name must match",
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			"os_distro": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Operating system distribution, e.g. ubuntu",
+			},
+			"os_version": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Operating system version, e.g. 22.04",
+			},
+			"visibility": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Image visibility: public, private, shared or community",
+				ValidateFunc: validation.StringInSlice(imageVisibilities, false),
+			},
+			"tags": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Tags the images must carry. All listed tags must be present",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "IDs of the matching images, newest first",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"images": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Matching images, newest first",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id":         {Type: schema.TypeString, Computed: true},
+						"name":       {Type: schema.TypeString, Computed: true},
+						"status":     {Type: schema.TypeString, Computed: true},
+						"os_distro":  {Type: schema.TypeString, Computed: true},
+						"os_version": {Type: schema.TypeString, Computed: true},
+						"visibility": {Type: schema.TypeString, Computed: true},
+						"tags": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"min_disk":   {Type: schema.TypeInt, Computed: true},
+						"min_ram":    {Type: schema.TypeInt, Computed: true},
+						"size":       {Type: schema.TypeInt, Computed: true},
+						"created_at": {Type: schema.TypeString, Computed: true},
+					},
+				},
+			},
+			"region":     helpers.DataSourceRegionSchema(),
+			"project_id": helpers.DataSourceProjectIDSchema(),
+		},
+	}
+}
+
+func dataSourceAceCloudImagesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := helpers.ScopedClient(d, meta)
+
+	all, err := c.ListImages(ctx, nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	images := filterImages(all, imageFilterFromResourceData(d))
+
+	ids := make([]string, 0, len(images))
+	out := make([]interface{}, 0, len(images))
+	for _, img := range images {
+		ids = append(ids, img.ID)
+		out = append(out, flattenImage(img))
+	}
+
+	d.SetId(helpers.HashStrings(ids))
+	_ = d.Set("ids", ids)
+	if err := d.Set("images", out); err != nil {
+		return diag.FromErr(err)
+	}
+
+	helpers.SetScope(d, c)
+
+	return nil
+}
@@ -0,0 +1,114 @@
+package datasources
+
+import (
+	"context"
+
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/client/types"
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/helpers"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// DataSourceAceCloudNetwork looks up exactly one network by ID, name or tags.
+func DataSourceAceCloudNetwork() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceAceCloudNetworkRead,
+
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "ID of the network",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Exact name of the network",
+			},
+			"tags": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Computed:    true,
+				Description: "Tags the network must carry. All listed tags must be present",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Current status of the network",
+			},
+			"shared": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the network is shared across projects",
+			},
+			"external": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the network provides external connectivity",
+			},
+			"subnets": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "IDs of the subnets in the network",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"region":     helpers.DataSourceRegionSchema(),
+			"project_id": helpers.DataSourceProjectIDSchema(),
+		},
+	}
+}
+
+func dataSourceAceCloudNetworkRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := helpers.ScopedClient(d, meta)
+
+	all, err := c.ListNetworks(ctx, nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	networks := filterNetworks(all, idNameTagsFilterFromResourceData(d))
+	if len(networks) == 0 {
+		return diag.Errorf("no network matches the given criteria")
+	}
+	if len(networks) > 1 {
+		return diag.Errorf("%d networks match the given criteria; use more specific criteria", len(networks))
+	}
+
+	n := networks[0]
+	d.SetId(n.ID)
+	_ = d.Set("name", n.Name)
+	_ = d.Set("tags", n.Tags)
+	_ = d.Set("status", n.Status)
+	_ = d.Set("shared", n.Shared)
+	_ = d.Set("external", n.External)
+	_ = d.Set("subnets", n.Subnets)
+
+	helpers.SetScope(d, c)
+
+	return nil
+}
+
+func filterNetworks(networks []types.Network, filter idNameTagsFilter) []types.Network {
+	out := make([]types.Network, 0, len(networks))
+	for _, n := range networks {
+		if filter.match(n.ID, n.Name, n.Tags) {
+			out = append(out, n)
+		}
+	}
+	return out
+}
+
+func flattenNetwork(n types.Network) map[string]interface{} {
+	return map[string]interface{}{
+		"id":       n.ID,
+		"name":     n.Name,
+		"tags":     n.Tags,
+		"status":   n.Status,
+		"shared":   n.Shared,
+		"external": n.External,
+		"subnets":  n.Subnets,
+	}
+}
@@ -0,0 +1,91 @@
+package datasources
+
+import (
+	"context"
+
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/helpers"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func DataSourceAceCloudNetworks() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceAceCloudNetworksRead,
+
+		Schema: map[string]*schema.Schema{
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Regular expression the network name must match",
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			"tags": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Tags the networks must carry. All listed tags must be present",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "IDs of the matching networks",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"networks": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Matching networks",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id":   {Type: schema.TypeString, Computed: true},
+						"name": {Type: schema.TypeString, Computed: true},
+						"tags": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"status":   {Type: schema.TypeString, Computed: true},
+						"shared":   {Type: schema.TypeBool, Computed: true},
+						"external": {Type: schema.TypeBool, Computed: true},
+						"subnets": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+			"region":     helpers.DataSourceRegionSchema(),
+			"project_id": helpers.DataSourceProjectIDSchema(),
+		},
+	}
+}
+
+func dataSourceAceCloudNetworksRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := helpers.ScopedClient(d, meta)
+
+	all, err := c.ListNetworks(ctx, nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	networks := filterNetworks(all, idNameTagsFilterFromResourceData(d))
+
+	ids := make([]string, 0, len(networks))
+	out := make([]interface{}, 0, len(networks))
+	for _, n := range networks {
+		ids = append(ids, n.ID)
+		out = append(out, flattenNetwork(n))
+	}
+
+	d.SetId(helpers.HashStrings(ids))
+	_ = d.Set("ids", ids)
+	if err := d.Set("networks", out); err != nil {
+		return diag.FromErr(err)
+	}
+
+	helpers.SetScope(d, c)
+
+	return nil
+}
@@ -0,0 +1,93 @@
+package datasources
+
+import (
+	"context"
+
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/client/types"
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/helpers"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// DataSourceAceCloudSecurityGroup looks up exactly one security group by ID,
+// name or tags.
+func DataSourceAceCloudSecurityGroup() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceAceCloudSecurityGroupRead,
+
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "ID of the security group",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Exact name of the security group",
+			},
+			"tags": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Computed:    true,
+				Description: "Tags the security group must carry. All listed tags must be present",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Description of the security group",
+			},
+			"region":     helpers.DataSourceRegionSchema(),
+			"project_id": helpers.DataSourceProjectIDSchema(),
+		},
+	}
+}
+
+func dataSourceAceCloudSecurityGroupRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := helpers.ScopedClient(d, meta)
+
+	all, err := c.ListSecurityGroups(ctx, nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	groups := filterSecurityGroups(all, idNameTagsFilterFromResourceData(d))
+	if len(groups) == 0 {
+		return diag.Errorf("no security group matches the given criteria")
+	}
+	if len(groups) > 1 {
+		return diag.Errorf("%d security groups match the given criteria; use more specific criteria", len(groups))
+	}
+
+	sg := groups[0]
+	d.SetId(sg.ID)
+	_ = d.Set("name", sg.Name)
+	_ = d.Set("tags", sg.Tags)
+	_ = d.Set("description", sg.Description)
+
+	helpers.SetScope(d, c)
+
+	return nil
+}
+
+func filterSecurityGroups(groups []types.SecurityGroup, filter idNameTagsFilter) []types.SecurityGroup {
+	out := make([]types.SecurityGroup, 0, len(groups))
+	for _, sg := range groups {
+		if filter.match(sg.ID, sg.Name, sg.Tags) {
+			out = append(out, sg)
+		}
+	}
+	return out
+}
+
+func flattenSecurityGroup(sg types.SecurityGroup) map[string]interface{} {
+	return map[string]interface{}{
+		"id":          sg.ID,
+		"name":        sg.Name,
+		"tags":        sg.Tags,
+		"description": sg.Description,
+	}
+}
@@ -0,0 +1,84 @@
+package datasources
+
+import (
+	"context"
+
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/helpers"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func DataSourceAceCloudSecurityGroups() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceAceCloudSecurityGroupsRead,
+
+		Schema: map[string]*schema.Schema{
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Regular expression the security group name must match",
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			"tags": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Tags the security groups must carry. All listed tags must be present",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "IDs of the matching security groups",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"security_groups": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Matching security groups",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id":   {Type: schema.TypeString, Computed: true},
+						"name": {Type: schema.TypeString, Computed: true},
+						"tags": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"description": {Type: schema.TypeString, Computed: true},
+					},
+				},
+			},
+			"region":     helpers.DataSourceRegionSchema(),
+			"project_id": helpers.DataSourceProjectIDSchema(),
+		},
+	}
+}
+
+func dataSourceAceCloudSecurityGroupsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := helpers.ScopedClient(d, meta)
+
+	all, err := c.ListSecurityGroups(ctx, nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	groups := filterSecurityGroups(all, idNameTagsFilterFromResourceData(d))
+
+	ids := make([]string, 0, len(groups))
+	out := make([]interface{}, 0, len(groups))
+	for _, sg := range groups {
+		ids = append(ids, sg.ID)
+		out = append(out, flattenSecurityGroup(sg))
+	}
+
+	d.SetId(helpers.HashStrings(ids))
+	_ = d.Set("ids", ids)
+	if err := d.Set("security_groups", out); err != nil {
+		return diag.FromErr(err)
+	}
+
+	helpers.SetScope(d, c)
+
+	return nil
+}
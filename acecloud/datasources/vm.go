@@ -0,0 +1,168 @@
+package datasources
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/client"
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/client/types"
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/helpers"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// DataSourceAceCloudVM looks up an existing instance by ID or by unique name.
+func DataSourceAceCloudVM() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceAceCloudVMRead,
+
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "ID of the VM instance",
+				ExactlyOneOf: []string{"id", "name"},
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Name of the VM instance. Must be unique in the region and project",
+				ExactlyOneOf: []string{"id", "name"},
+			},
+			"instance_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The VM instance ID",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Current status of the VM instance",
+			},
+			"ip_address": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "IP address of the VM instance",
+			},
+			"availability_zone": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Availability zone of the VM",
+			},
+			"flavor": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Flavor ID of the VM instance",
+			},
+			"key": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "SSH key of the VM",
+			},
+			"tags": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Tags of the VM instance",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"created_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Creation timestamp of the VM instance",
+			},
+			"region":     helpers.DataSourceRegionSchema(),
+			"project_id": helpers.DataSourceProjectIDSchema(),
+		},
+	}
+}
+
+func dataSourceAceCloudVMRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := helpers.ScopedClient(d, meta)
+
+	var vm types.VM
+	if v, ok := d.GetOk("id"); ok {
+		resp, err := c.GetVM(ctx, v.(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		vm = *resp
+	} else {
+		name := d.Get("name").(string)
+		// The name filter narrows the listing server-side; names are still
+		// matched exactly below.
+		all, err := c.ListVMs(ctx, &client.ListOptions{Filters: url.Values{"name": {name}}})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		vms := filterVMs(all, vmFilter{Name: func(n string) bool { return n == name }})
+		if len(vms) == 0 {
+			return diag.Errorf("no VM named %q found", name)
+		}
+		if len(vms) > 1 {
+			return diag.Errorf("%d VMs are named %q; look the VM up by id instead", len(vms), name)
+		}
+		vm = vms[0]
+	}
+
+	d.SetId(vm.ID)
+	_ = d.Set("name", vm.Name)
+	_ = d.Set("instance_id", vm.ID)
+	_ = d.Set("status", vm.Status)
+	_ = d.Set("ip_address", vm.PublicIP())
+	_ = d.Set("availability_zone", vm.AvailabilityZone)
+	_ = d.Set("flavor", vm.Flavor)
+	_ = d.Set("key", vm.Key)
+	_ = d.Set("tags", vm.Tags)
+	_ = d.Set("created_at", vm.CreatedAt)
+
+	helpers.SetScope(d, c)
+
+	return nil
+}
+
+type vmFilter struct {
+	Name             func(string) bool
+	Status           string
+	AvailabilityZone string
+	Flavor           string
+	Tags             []string
+}
+
+func filterVMs(vms []types.VM, filter vmFilter) []types.VM {
+	out := make([]types.VM, 0, len(vms))
+	for _, vm := range vms {
+		if filter.Name != nil && !filter.Name(vm.Name) {
+			continue
+		}
+		if filter.Status != "" && vm.Status != filter.Status {
+			continue
+		}
+		if filter.AvailabilityZone != "" && vm.AvailabilityZone != filter.AvailabilityZone {
+			continue
+		}
+		if filter.Flavor != "" && vm.Flavor != filter.Flavor {
+			continue
+		}
+		if !hasAllTags(vm.Tags, filter.Tags) {
+			continue
+		}
+		out = append(out, vm)
+	}
+	return out
+}
+
+func flattenVM(vm types.VM) map[string]interface{} {
+	return map[string]interface{}{
+		"id":                vm.ID,
+		"name":              vm.Name,
+		"status":            vm.Status,
+		"ip_address":        vm.PublicIP(),
+		"availability_zone": vm.AvailabilityZone,
+		"flavor":            vm.Flavor,
+		"key":               vm.Key,
+		"tags":              vm.Tags,
+		"created_at":        vm.CreatedAt,
+	}
+}
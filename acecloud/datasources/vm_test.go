@@ -0,0 +1,103 @@
+package datasources
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/client/types"
+)
+
+var testVMs = []types.VM{
+	{ID: "vm-1", Name: "web-1", Status: "ACTIVE", AvailabilityZone: "zone-a", Flavor: "C.2x4", Tags: []string{"prod", "web"}},
+	{ID: "vm-2", Name: "web-10", Status: "ACTIVE", AvailabilityZone: "zone-b", Flavor: "C.2x4", Tags: []string{"prod"}},
+	{ID: "vm-3", Name: "web-2", Status: "SHUTOFF", AvailabilityZone: "zone-a", Flavor: "C.4x8", Tags: []string{"staging"}},
+	{ID: "vm-4", Name: "db-1", Status: "ACTIVE", AvailabilityZone: "zone-a", Flavor: "C.4x8", Tags: []string{"prod"}},
+}
+
+func vmIDs(vms []types.VM) string {
+	var ids []string
+	for _, vm := range vms {
+		ids = append(ids, vm.ID)
+	}
+	return strings.Join(ids, " ")
+}
+
+func TestFilterVMs(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		filter vmFilter
+		want   string
+	}{
+		{"no filter", vmFilter{}, "vm-1 vm-2 vm-3 vm-4"},
+		{"name", vmFilter{Name: func(n string) bool { return n == "web-1" }}, "vm-1"},
+		{"status", vmFilter{Status: "ACTIVE"}, "vm-1 vm-2 vm-4"},
+		{"availability zone", vmFilter{AvailabilityZone: "zone-a"}, "vm-1 vm-3 vm-4"},
+		{"flavor", vmFilter{Flavor: "C.4x8"}, "vm-3 vm-4"},
+		{"tags", vmFilter{Tags: []string{"prod", "web"}}, "vm-1"},
+		{"combined", vmFilter{Status: "ACTIVE", AvailabilityZone: "zone-a", Tags: []string{"prod"}}, "vm-1 vm-4"},
+		{"no match", vmFilter{Status: "ERROR"}, ""},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := vmIDs(filterVMs(testVMs, tc.filter)); got != tc.want {
+				t.Errorf("filterVMs = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestVMReadByName(t *testing.T) {
+	// The server filter on name is not exact, so it may return web-10 for
+	// web-1.
+	var requests []*http.Request
+	c := listClient(t, testVMs[:2], &requests)
+
+	d, diags := read(t, DataSourceAceCloudVM(), map[string]any{"name": "web-1"}, c)
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	if d.Id() != "vm-1" || d.Get("instance_id") != "vm-1" || d.Get("availability_zone") != "zone-a" {
+		t.Errorf("read VM %q in %q", d.Id(), d.Get("availability_zone"))
+	}
+	if len(requests) != 1 || requests[0].URL.Query().Get("name") != "web-1" {
+		t.Errorf("requests = %v, want one list filtered by name", requests)
+	}
+
+	_, diags = read(t, DataSourceAceCloudVM(), map[string]any{"name": "web-3"}, c)
+	wantError(t, diags, `no VM named "web-3" found`)
+
+	duplicate := append(testVMs[:1:1], types.VM{ID: "vm-5", Name: "web-1"})
+	_, diags = read(t, DataSourceAceCloudVM(), map[string]any{"name": "web-1"}, listClient(t, duplicate, nil))
+	wantError(t, diags, `2 VMs are named "web-1"; look the VM up by id instead`)
+}
+
+func TestVMReadByID(t *testing.T) {
+	var requests []*http.Request
+	d, diags := read(t, DataSourceAceCloudVM(), map[string]any{"id": "vm-3"}, listClient(t, testVMs[2], &requests))
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	if d.Get("name") != "web-2" || d.Get("status") != "SHUTOFF" {
+		t.Errorf("read VM named %q with status %q", d.Get("name"), d.Get("status"))
+	}
+	if len(requests) != 1 || !strings.HasSuffix(requests[0].URL.Path, "/instances/vm-3") {
+		t.Errorf("requests = %v, want one get of vm-3", requests)
+	}
+}
+
+func TestVMsRead(t *testing.T) {
+	d, diags := read(t, DataSourceAceCloudVMs(), map[string]any{
+		"name_regex": "^web-",
+		"status":     "ACTIVE",
+		"tags":       []any{"prod"},
+	}, listClient(t, testVMs, nil))
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	if got := d.Get("ids").([]any); len(got) != 2 || got[0] != "vm-1" || got[1] != "vm-2" {
+		t.Errorf("ids = %v", got)
+	}
+	if d.Get("vms.1.name") != "web-10" || d.Get("vms.1.availability_zone") != "zone-b" {
+		t.Errorf("vms.1 = %v", d.Get("vms.1"))
+	}
+}
@@ -0,0 +1,117 @@
+package datasources
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/helpers"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func DataSourceAceCloudVMs() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceAceCloudVMsRead,
+
+		Schema: map[string]*schema.Schema{
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Regular expression the VM name must match",
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return VMs with this status",
+			},
+			"availability_zone": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return VMs in this availability zone",
+			},
+			"flavor": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return VMs with this flavor ID",
+			},
+			"tags": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Tags the VMs must carry. All listed tags must be present",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "IDs of the matching VMs",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"vms": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Matching VMs",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id":                {Type: schema.TypeString, Computed: true},
+						"name":              {Type: schema.TypeString, Computed: true},
+						"status":            {Type: schema.TypeString, Computed: true},
+						"ip_address":        {Type: schema.TypeString, Computed: true},
+						"availability_zone": {Type: schema.TypeString, Computed: true},
+						"flavor":            {Type: schema.TypeString, Computed: true},
+						"key":               {Type: schema.TypeString, Computed: true},
+						"tags": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"created_at": {Type: schema.TypeString, Computed: true},
+					},
+				},
+			},
+			"region":     helpers.DataSourceRegionSchema(),
+			"project_id": helpers.DataSourceProjectIDSchema(),
+		},
+	}
+}
+
+func dataSourceAceCloudVMsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := helpers.ScopedClient(d, meta)
+
+	all, err := c.ListVMs(ctx, nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	filter := vmFilter{
+		Status:           d.Get("status").(string),
+		AvailabilityZone: d.Get("availability_zone").(string),
+		Flavor:           d.Get("flavor").(string),
+	}
+	if v, ok := d.GetOk("name_regex"); ok {
+		filter.Name = regexp.MustCompile(v.(string)).MatchString
+	}
+	if v, ok := d.GetOk("tags"); ok {
+		filter.Tags = helpers.InterfaceSliceToStringSlice(v.(*schema.Set).List())
+	}
+
+	vms := filterVMs(all, filter)
+
+	ids := make([]string, 0, len(vms))
+	out := make([]interface{}, 0, len(vms))
+	for _, vm := range vms {
+		ids = append(ids, vm.ID)
+		out = append(out, flattenVM(vm))
+	}
+
+	d.SetId(helpers.HashStrings(ids))
+	_ = d.Set("ids", ids)
+	if err := d.Set("vms", out); err != nil {
+		return diag.FromErr(err)
+	}
+
+	helpers.SetScope(d, c)
+
+	return nil
+}
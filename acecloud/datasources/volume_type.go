@@ -0,0 +1,102 @@
+package datasources
+
+import (
+	"context"
+
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/client/types"
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/helpers"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// DataSourceAceCloudVolumeType looks up a volume type by ID or name. When
+// neither is given the region's default volume type is returned.
+func DataSourceAceCloudVolumeType() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceAceCloudVolumeTypeRead,
+
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "ID of the volume type",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Exact name of the volume type, as used in the volume_type argument of acecloud_vm volumes",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Description of the volume type",
+			},
+			"is_default": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether this is the default volume type of the region",
+			},
+			"region":     helpers.DataSourceRegionSchema(),
+			"project_id": helpers.DataSourceProjectIDSchema(),
+		},
+	}
+}
+
+func dataSourceAceCloudVolumeTypeRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := helpers.ScopedClient(d, meta)
+
+	all, err := c.ListVolumeTypes(ctx, nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	filter := idNameTagsFilterFromResourceData(d)
+	volumeTypes := filterVolumeTypes(all, filter)
+	if filter.ID == "" && filter.Name == "" {
+		defaults := make([]types.VolumeType, 0, 1)
+		for _, vt := range volumeTypes {
+			if vt.IsDefault {
+				defaults = append(defaults, vt)
+			}
+		}
+		volumeTypes = defaults
+	}
+
+	if len(volumeTypes) == 0 {
+		return diag.Errorf("no volume type matches the given criteria")
+	}
+	if len(volumeTypes) > 1 {
+		return diag.Errorf("%d volume types match the given criteria; use more specific criteria", len(volumeTypes))
+	}
+
+	vt := volumeTypes[0]
+	d.SetId(vt.ID)
+	_ = d.Set("name", vt.Name)
+	_ = d.Set("description", vt.Description)
+	_ = d.Set("is_default", vt.IsDefault)
+
+	helpers.SetScope(d, c)
+
+	return nil
+}
+
+func filterVolumeTypes(volumeTypes []types.VolumeType, filter idNameTagsFilter) []types.VolumeType {
+	out := make([]types.VolumeType, 0, len(volumeTypes))
+	for _, vt := range volumeTypes {
+		if filter.match(vt.ID, vt.Name, nil) {
+			out = append(out, vt)
+		}
+	}
+	return out
+}
+
+func flattenVolumeType(vt types.VolumeType) map[string]interface{} {
+	return map[string]interface{}{
+		"id":          vt.ID,
+		"name":        vt.Name,
+		"description": vt.Description,
+		"is_default":  vt.IsDefault,
+	}
+}
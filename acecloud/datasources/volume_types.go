@@ -0,0 +1,83 @@
+package datasources
+
+import (
+	"context"
+
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/helpers"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func DataSourceAceCloudVolumeTypes() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceAceCloudVolumeTypesRead,
+
+		Schema: map[string]*schema.Schema{
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Regular expression the volume type name must match",
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			"ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "IDs of the matching volume types",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"names": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Names of the matching volume types",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"volume_types": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Matching volume types",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id":          {Type: schema.TypeString, Computed: true},
+						"name":        {Type: schema.TypeString, Computed: true},
+						"description": {Type: schema.TypeString, Computed: true},
+						"is_default":  {Type: schema.TypeBool, Computed: true},
+					},
+				},
+			},
+			"region":     helpers.DataSourceRegionSchema(),
+			"project_id": helpers.DataSourceProjectIDSchema(),
+		},
+	}
+}
+
+func dataSourceAceCloudVolumeTypesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := helpers.ScopedClient(d, meta)
+
+	all, err := c.ListVolumeTypes(ctx, nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	volumeTypes := filterVolumeTypes(all, idNameTagsFilterFromResourceData(d))
+
+	ids := make([]string, 0, len(volumeTypes))
+	names := make([]string, 0, len(volumeTypes))
+	out := make([]interface{}, 0, len(volumeTypes))
+	for _, vt := range volumeTypes {
+		ids = append(ids, vt.ID)
+		names = append(names, vt.Name)
+		out = append(out, flattenVolumeType(vt))
+	}
+
+	d.SetId(helpers.HashStrings(ids))
+	_ = d.Set("ids", ids)
+	_ = d.Set("names", names)
+	if err := d.Set("volume_types", out); err != nil {
+		return diag.FromErr(err)
+	}
+
+	helpers.SetScope(d, c)
+
+	return nil
+}
@@ -0,0 +1,180 @@
+package acecloud
+
+import (
+	"context"
+
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/fwephemeral"
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/fwfunctions"
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	sdkschema "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// frameworkProvider serves the resources written on terraform-plugin-framework.
+// It is muxed with the SDK provider, which owns provider configuration: the
+// SDK provider is configured first and this provider reuses its client.
+type frameworkProvider struct {
+	sdkProvider *sdkschema.Provider
+	version     string
+
+	resources     []func() resource.Resource
+	listResources []func() list.ListResource
+}
+
+var (
+	_ provider.Provider                       = &frameworkProvider{}
+	_ provider.ProviderWithEphemeralResources = &frameworkProvider{}
+	_ provider.ProviderWithFunctions          = &frameworkProvider{}
+	_ provider.ProviderWithListResources      = &frameworkProvider{}
+)
+
+func (p *frameworkProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
+	resp.TypeName = "acecloud"
+	resp.Version = p.version
+}
+
+// Schema mirrors the SDK provider schema. terraform-plugin-mux refuses to
+// serve providers whose schemas differ.
+func (p *frameworkProvider) Schema(ctx context.Context, req provider.SchemaRequest, resp *provider.SchemaResponse) {
+	endpoints := make(map[string]schema.Attribute, len(client.Services))
+	for _, svc := range client.Services {
+		endpoints[svc.Key] = schema.StringAttribute{
+			Optional:    true,
+			Description: endpointDescription(svc),
+		}
+	}
+
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"api_endpoint": schema.StringAttribute{
+				Optional:    true,
+				Description: descriptions["api_endpoint"],
+			},
+			"api_key": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: descriptions["api_key"],
+			},
+			"profile": schema.StringAttribute{
+				Optional:    true,
+				Description: descriptions["profile"],
+			},
+			"region": schema.StringAttribute{
+				Optional:    true,
+				Description: descriptions["region"],
+			},
+			"project_id": schema.StringAttribute{
+				Optional:    true,
+				Description: descriptions["project_id"],
+			},
+			"client_id": schema.Int64Attribute{
+				Optional:    true,
+				Description: descriptions["client_id"],
+			},
+			"user_id": schema.Int64Attribute{
+				Optional:    true,
+				Description: descriptions["user_id"],
+			},
+			"list_page_size": schema.Int64Attribute{
+				Optional:    true,
+				Description: descriptions["list_page_size"],
+			},
+			"max_requests_per_second": schema.Float64Attribute{
+				Optional:    true,
+				Description: descriptions["max_requests_per_second"],
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				Optional:    true,
+				Description: descriptions["max_concurrent_requests"],
+			},
+			"skip_credentials_validation": schema.BoolAttribute{
+				Optional:    true,
+				Description: descriptions["skip_credentials_validation"],
+			},
+			"ca_cert_file": schema.StringAttribute{
+				Optional:    true,
+				Description: descriptions["ca_cert_file"],
+			},
+			"ca_cert_pem": schema.StringAttribute{
+				Optional:    true,
+				Description: descriptions["ca_cert_pem"],
+			},
+			"client_cert": schema.StringAttribute{
+				Optional:    true,
+				Description: descriptions["client_cert"],
+			},
+			"client_key": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: descriptions["client_key"],
+			},
+			"insecure": schema.BoolAttribute{
+				Optional:    true,
+				Description: descriptions["insecure"],
+			},
+			"proxy_url": schema.StringAttribute{
+				Optional:    true,
+				Description: descriptions["proxy_url"],
+			},
+			"request_timeout": schema.StringAttribute{
+				Optional:    true,
+				Description: descriptions["request_timeout"],
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"endpoints": schema.ListNestedBlock{
+				Description: descriptions["endpoints"],
+				NestedObject: schema.NestedBlockObject{
+					Attributes: endpoints,
+				},
+			},
+		},
+	}
+}
+
+// Configure hands the client built by the SDK provider to framework resources.
+// The configuration itself was already validated there.
+func (p *frameworkProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+	c, ok := p.sdkProvider.Meta().(*client.AceCloudClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Provider not configured",
+			"The AceCloud client was not created by the SDK provider. This is a bug in the provider; please report it.",
+		)
+		return
+	}
+
+	resp.ResourceData = c
+	resp.DataSourceData = c
+	resp.EphemeralResourceData = c
+	resp.ListResourceData = c
+}
+
+func (p *frameworkProvider) Resources(ctx context.Context) []func() resource.Resource {
+	return p.resources
+}
+
+func (p *frameworkProvider) ListResources(ctx context.Context) []func() list.ListResource {
+	return p.listResources
+}
+
+func (p *frameworkProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		fwephemeral.NewVMPasswordResource,
+		fwephemeral.NewKubeconfigResource,
+	}
+}
+
+func (p *frameworkProvider) Functions(ctx context.Context) []func() function.Function {
+	return fwfunctions.Functions()
+}
+
+func (p *frameworkProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
+	return nil
+}
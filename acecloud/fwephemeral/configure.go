@@ -0,0 +1,46 @@
+// Package fwephemeral holds the provider's ephemeral resources. Their results
+// are only available during a single Terraform run and are never written to
+// plan or state, which makes them the place to fetch secrets.
+package fwephemeral
+
+import (
+	"fmt"
+
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+)
+
+// clientFromProviderData extracts the client handed over by the provider's
+// Configure. It returns nil without error before the provider is configured.
+func clientFromProviderData(data any, diags *diag.Diagnostics) *client.AceCloudClient {
+	if data == nil {
+		return nil
+	}
+
+	c, ok := data.(*client.AceCloudClient)
+	if !ok {
+		diags.AddError(
+			"Unexpected provider data",
+			fmt.Sprintf("Expected *client.AceCloudClient, got %T. This is a bug in the provider; please report it.", data),
+		)
+		return nil
+	}
+	return c
+}
+
+func regionAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Optional:    true,
+		Computed:    true,
+		Description: "Region to read from. Defaults to the provider region",
+	}
+}
+
+func projectIDAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Optional:    true,
+		Computed:    true,
+		Description: "Project to read from. Defaults to the provider project_id",
+	}
+}
@@ -0,0 +1,204 @@
+package fwephemeral
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// open calls r.Open with config holding attrs and null everywhere else, as
+// the framework server does, and returns the result's attributes.
+func open(t *testing.T, r ephemeral.EphemeralResource, attrs map[string]string) (map[string]tftypes.Value, diag.Diagnostics) {
+	t.Helper()
+	ctx := context.Background()
+
+	var schemaResp ephemeral.SchemaResponse
+	r.Schema(ctx, ephemeral.SchemaRequest{}, &schemaResp)
+	typ := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	vals := map[string]tftypes.Value{}
+	for name, attrType := range typ.AttributeTypes {
+		vals[name] = tftypes.NewValue(attrType, nil)
+	}
+	for name, v := range attrs {
+		vals[name] = tftypes.NewValue(tftypes.String, v)
+	}
+
+	req := ephemeral.OpenRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(typ, vals)}}
+	resp := ephemeral.OpenResponse{Result: tfsdk.EphemeralResultData{Schema: schemaResp.Schema, Raw: tftypes.NewValue(typ, nil)}}
+	r.Open(ctx, req, &resp)
+	if resp.Diagnostics.HasError() {
+		return nil, resp.Diagnostics
+	}
+
+	var result map[string]tftypes.Value
+	if err := resp.Result.Raw.As(&result); err != nil {
+		t.Fatal(err)
+	}
+	return result, resp.Diagnostics
+}
+
+func stringValue(t *testing.T, v tftypes.Value) string {
+	t.Helper()
+	var s string
+	if err := v.As(&s); err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func wantError(t *testing.T, diags diag.Diagnostics, summary, detail string) {
+	t.Helper()
+	if !diags.HasError() {
+		t.Fatal("Open succeeded")
+	}
+	d := diags.Errors()[0]
+	if d.Summary() != summary || !strings.Contains(d.Detail(), detail) {
+		t.Errorf("error = %q: %q, want %q containing %q", d.Summary(), d.Detail(), summary, detail)
+	}
+}
+
+// The region and project_id results are filled in from the provider when
+// config leaves them out, so the schema must let Open compute them.
+func TestScopeAttributesComputed(t *testing.T) {
+	for _, r := range []ephemeral.EphemeralResource{NewVMPasswordResource(), NewKubeconfigResource()} {
+		var resp ephemeral.SchemaResponse
+		r.Schema(context.Background(), ephemeral.SchemaRequest{}, &resp)
+		for _, name := range []string{"region", "project_id"} {
+			attr := resp.Schema.Attributes[name].(schema.StringAttribute)
+			if !attr.Optional || !attr.Computed {
+				t.Errorf("%s is Optional %t, Computed %t; want both", name, attr.Optional, attr.Computed)
+			}
+		}
+	}
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func stubClient(status int, body string, requests *[]*http.Request) *client.AceCloudClient {
+	c := client.NewAceCloudClient("https://api.acecloud.test", "test-api-key", "test-region", "test-project")
+	c.HTTPClient = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		*requests = append(*requests, req)
+		return &http.Response{
+			StatusCode: status,
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    req,
+		}, nil
+	})}
+	return c
+}
+
+func TestVMPasswordOpen(t *testing.T) {
+	var requests []*http.Request
+	r := &vmPasswordResource{client: stubClient(http.StatusOK, `{"data":{"password":"s3cret"}}`, &requests)}
+
+	result, diags := open(t, r, map[string]string{"instance_id": "vm-1"})
+	if diags.HasError() {
+		t.Fatalf("Open: %v", diags)
+	}
+	for name, want := range map[string]string{
+		"password":   "s3cret",
+		"region":     "test-region",
+		"project_id": "test-project",
+	} {
+		if got := stringValue(t, result[name]); got != want {
+			t.Errorf("%s = %q, want %q", name, got, want)
+		}
+	}
+
+	if len(requests) != 1 || requests[0].URL.Path != "/cloud/instances/vm-1/password" {
+		t.Errorf("requests = %v, want one get of the vm-1 password", requests)
+	}
+}
+
+func TestVMPasswordOpenError(t *testing.T) {
+	var requests []*http.Request
+	r := &vmPasswordResource{client: stubClient(http.StatusNotFound, `{"error":true,"message":"instance not found"}`, &requests)}
+	_, diags := open(t, r, map[string]string{"instance_id": "missing"})
+	wantError(t, diags, "Error reading VM password", "API error 404: instance not found")
+}
+
+func TestKubeconfigOpen(t *testing.T) {
+	var requests []*http.Request
+	r := &kubeconfigResource{client: stubClient(http.StatusOK, `{"data":{
+		"kubeconfig": "apiVersion: v1",
+		"host": "https://k8s.test:6443",
+		"cluster_ca_certificate": "CA",
+		"token": "t0ken",
+		"expires_at": "2026-01-01T00:00:00Z"
+	}}`, &requests)}
+
+	result, diags := open(t, r, map[string]string{"cluster_id": "cluster-1", "region": "other-region"})
+	if diags.HasError() {
+		t.Fatalf("Open: %v", diags)
+	}
+	for name, want := range map[string]string{
+		"kubeconfig":             "apiVersion: v1",
+		"host":                   "https://k8s.test:6443",
+		"cluster_ca_certificate": "CA",
+		"token":                  "t0ken",
+		"expires_at":             "2026-01-01T00:00:00Z",
+		"region":                 "other-region",
+		"project_id":             "test-project",
+	} {
+		if got := stringValue(t, result[name]); got != want {
+			t.Errorf("%s = %q, want %q", name, got, want)
+		}
+	}
+
+	if len(requests) != 1 {
+		t.Fatalf("sent %d requests, want 1", len(requests))
+	}
+	if got := requests[0].URL; got.Path != "/kubernetes/clusters/cluster-1/kubeconfig" || got.Query().Get("region") != "other-region" {
+		t.Errorf("requested %s", got)
+	}
+}
+
+func TestKubeconfigOpenErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		status int
+		body   string
+		detail string
+	}{
+		{"not found", http.StatusNotFound, `{"error":true,"message":"cluster not found"}`, "API error 404: cluster not found"},
+		{"forbidden", http.StatusForbidden, `{"error":true,"message":"access denied"}`, "API error 403: access denied"},
+		{"envelope error", http.StatusOK, `{"error":true,"message":"cluster is provisioning"}`, "API returned error: cluster is provisioning"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var requests []*http.Request
+			r := &kubeconfigResource{client: stubClient(tc.status, tc.body, &requests)}
+			_, diags := open(t, r, map[string]string{"cluster_id": "cluster-1"})
+			wantError(t, diags, "Error reading kubeconfig", tc.detail)
+		})
+	}
+}
+
+func TestClientFromProviderData(t *testing.T) {
+	var diags diag.Diagnostics
+	if c := clientFromProviderData(nil, &diags); c != nil || diags.HasError() {
+		t.Errorf("nil provider data returned %v, %v", c, diags)
+	}
+
+	c := client.NewAceCloudClient("https://api.acecloud.test", "key", "", "")
+	if got := clientFromProviderData(c, &diags); got != c || diags.HasError() {
+		t.Errorf("client provider data returned %v, %v", got, diags)
+	}
+
+	if got := clientFromProviderData("not a client", &diags); got != nil || !diags.HasError() {
+		t.Errorf("unexpected provider data returned %v, %v", got, diags)
+	}
+}
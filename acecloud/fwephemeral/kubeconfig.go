@@ -0,0 +1,105 @@
+package fwephemeral
+
+import (
+	"context"
+
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// NewKubeconfigResource returns the acecloud_kubeconfig ephemeral resource.
+func NewKubeconfigResource() ephemeral.EphemeralResource {
+	return &kubeconfigResource{}
+}
+
+type kubeconfigResource struct {
+	client *client.AceCloudClient
+}
+
+type kubeconfigModel struct {
+	ClusterID            types.String `tfsdk:"cluster_id"`
+	Kubeconfig           types.String `tfsdk:"kubeconfig"`
+	Host                 types.String `tfsdk:"host"`
+	ClusterCACertificate types.String `tfsdk:"cluster_ca_certificate"`
+	Token                types.String `tfsdk:"token"`
+	ExpiresAt            types.String `tfsdk:"expires_at"`
+	Region               types.String `tfsdk:"region"`
+	ProjectID            types.String `tfsdk:"project_id"`
+}
+
+var (
+	_ ephemeral.EphemeralResource              = &kubeconfigResource{}
+	_ ephemeral.EphemeralResourceWithConfigure = &kubeconfigResource{}
+)
+
+func (r *kubeconfigResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_kubeconfig"
+}
+
+func (r *kubeconfigResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Issues short-lived credentials for a Kubernetes cluster without storing them in state.",
+		Attributes: map[string]schema.Attribute{
+			"cluster_id": schema.StringAttribute{
+				Required:    true,
+				Description: "ID of the Kubernetes cluster",
+			},
+			"kubeconfig": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "Complete kubeconfig document for the cluster",
+			},
+			"host": schema.StringAttribute{
+				Computed:    true,
+				Description: "URL of the cluster API server",
+			},
+			"cluster_ca_certificate": schema.StringAttribute{
+				Computed:    true,
+				Description: "PEM-encoded CA certificate of the cluster API server",
+			},
+			"token": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "Bearer token for the cluster API server",
+			},
+			"expires_at": schema.StringAttribute{
+				Computed:    true,
+				Description: "RFC 3339 timestamp at which the credentials expire",
+			},
+			"region":     regionAttribute(),
+			"project_id": projectIDAttribute(),
+		},
+	}
+}
+
+func (r *kubeconfigResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	r.client = clientFromProviderData(req.ProviderData, &resp.Diagnostics)
+}
+
+func (r *kubeconfigResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data kubeconfigModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	c := r.client.WithScope(data.Region.ValueString(), data.ProjectID.ValueString())
+
+	kc, err := c.GetKubeconfig(ctx, data.ClusterID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading kubeconfig", err.Error())
+		return
+	}
+
+	data.Kubeconfig = types.StringValue(kc.Raw)
+	data.Host = types.StringValue(kc.Host)
+	data.ClusterCACertificate = types.StringValue(kc.ClusterCACertificate)
+	data.Token = types.StringValue(kc.Token)
+	data.ExpiresAt = types.StringValue(kc.ExpiresAt)
+	data.Region = types.StringValue(c.Region)
+	data.ProjectID = types.StringValue(c.ProjectID)
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
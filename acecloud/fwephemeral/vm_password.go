@@ -0,0 +1,80 @@
+package fwephemeral
+
+import (
+	"context"
+
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// NewVMPasswordResource returns the acecloud_vm_password ephemeral resource.
+func NewVMPasswordResource() ephemeral.EphemeralResource {
+	return &vmPasswordResource{}
+}
+
+type vmPasswordResource struct {
+	client *client.AceCloudClient
+}
+
+type vmPasswordModel struct {
+	InstanceID types.String `tfsdk:"instance_id"`
+	Password   types.String `tfsdk:"password"`
+	Region     types.String `tfsdk:"region"`
+	ProjectID  types.String `tfsdk:"project_id"`
+}
+
+var (
+	_ ephemeral.EphemeralResource              = &vmPasswordResource{}
+	_ ephemeral.EphemeralResourceWithConfigure = &vmPasswordResource{}
+)
+
+func (r *vmPasswordResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_vm_password"
+}
+
+func (r *vmPasswordResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Fetches the administrator password generated for a VM without storing it in state.",
+		Attributes: map[string]schema.Attribute{
+			"instance_id": schema.StringAttribute{
+				Required:    true,
+				Description: "ID of the VM instance",
+			},
+			"password": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "Administrator password of the VM",
+			},
+			"region":     regionAttribute(),
+			"project_id": projectIDAttribute(),
+		},
+	}
+}
+
+func (r *vmPasswordResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	r.client = clientFromProviderData(req.ProviderData, &resp.Diagnostics)
+}
+
+func (r *vmPasswordResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data vmPasswordModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	c := r.client.WithScope(data.Region.ValueString(), data.ProjectID.ValueString())
+
+	pw, err := c.GetVMPassword(ctx, data.InstanceID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading VM password", err.Error())
+		return
+	}
+
+	data.Password = types.StringValue(pw.Password)
+	data.Region = types.StringValue(c.Region)
+	data.ProjectID = types.StringValue(c.ProjectID)
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
@@ -0,0 +1,106 @@
+package fwfunctions
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net/netip"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// NewCIDRSubnetsForAZFunction returns provider::acecloud::cidr_subnets_for_az.
+func NewCIDRSubnetsForAZFunction() function.Function {
+	return &cidrSubnetsForAZFunction{}
+}
+
+type cidrSubnetsForAZFunction struct{}
+
+func (f *cidrSubnetsForAZFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "cidr_subnets_for_az"
+}
+
+func (f *cidrSubnetsForAZFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Carve one subnet per availability zone out of a CIDR block",
+		Description: "Splits a CIDR block into consecutive subnets that are newbits longer than the block, and returns " +
+			"a map from each availability zone to its subnet. The nth zone gets the nth subnet, so the map stays " +
+			"stable as long as zones are only appended to the list.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "cidr",
+				Description: "The IPv4 or IPv6 CIDR block to split",
+			},
+			function.ListParameter{
+				Name:        "zones",
+				ElementType: types.StringType,
+				Description: "The availability zones to allocate subnets for",
+			},
+			function.Int64Parameter{
+				Name:        "newbits",
+				Description: "How many bits to add to the prefix length of cidr for each subnet",
+			},
+		},
+		Return: function.MapReturn{
+			ElementType: types.StringType,
+		},
+	}
+}
+
+func (f *cidrSubnetsForAZFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var cidr string
+	var zones []string
+	var newbits int64
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &cidr, &zones, &newbits))
+	if resp.Error != nil {
+		return
+	}
+
+	subnets, err := cidrSubnetsForAZ(cidr, zones, newbits)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, subnets))
+}
+
+func cidrSubnetsForAZ(cidr string, zones []string, newbits int64) (map[string]string, error) {
+	prefix, err := netip.ParsePrefix(cidr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CIDR block %q: %w", cidr, err)
+	}
+	prefix = prefix.Masked()
+
+	addrBits := int64(prefix.Addr().BitLen())
+	newLen := int64(prefix.Bits()) + newbits
+	if newbits < 1 || newLen > addrBits {
+		return nil, fmt.Errorf("newbits must be between 1 and %d for %s", addrBits-int64(prefix.Bits()), prefix)
+	}
+
+	available := new(big.Int).Lsh(big.NewInt(1), uint(newbits))
+	if big.NewInt(int64(len(zones))).Cmp(available) > 0 {
+		return nil, fmt.Errorf("%s has room for %s subnets of /%d, but %d zones were given", prefix, available, newLen, len(zones))
+	}
+
+	base := new(big.Int).SetBytes(prefix.Addr().AsSlice())
+	shift := uint(addrBits - newLen)
+
+	subnets := make(map[string]string, len(zones))
+	for i, zone := range zones {
+		if _, ok := subnets[zone]; ok {
+			return nil, fmt.Errorf("zone %q is listed more than once", zone)
+		}
+
+		offset := new(big.Int).Lsh(big.NewInt(int64(i)), shift)
+		raw := new(big.Int).Add(base, offset).FillBytes(make([]byte, addrBits/8))
+
+		addr, _ := netip.AddrFromSlice(raw)
+		if prefix.Addr().Is4() {
+			addr = addr.Unmap()
+		}
+		subnets[zone] = netip.PrefixFrom(addr, int(newLen)).String()
+	}
+	return subnets, nil
+}
@@ -0,0 +1,90 @@
+package fwfunctions
+
+import (
+	"context"
+	"maps"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestCIDRSubnetsForAZ(t *testing.T) {
+	cases := []struct {
+		cidr    string
+		zones   []string
+		newbits int64
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			cidr:    "10.0.0.0/16",
+			zones:   []string{"az-a", "az-b", "az-c"},
+			newbits: 8,
+			want:    map[string]string{"az-a": "10.0.0.0/24", "az-b": "10.0.1.0/24", "az-c": "10.0.2.0/24"},
+		},
+		{
+			// Host bits in the block are ignored.
+			cidr:    "10.0.5.7/16",
+			zones:   []string{"az-a", "az-b"},
+			newbits: 4,
+			want:    map[string]string{"az-a": "10.0.0.0/20", "az-b": "10.0.16.0/20"},
+		},
+		{
+			cidr:    "fd00:1::/48",
+			zones:   []string{"az-a", "az-b"},
+			newbits: 16,
+			want:    map[string]string{"az-a": "fd00:1::/64", "az-b": "fd00:1:0:1::/64"},
+		},
+		{cidr: "10.0.0.0/24", zones: []string{}, newbits: 2, want: map[string]string{}},
+		{cidr: "10.0.0.0/24", zones: []string{"a", "b", "c"}, newbits: 1, wantErr: true},
+		{cidr: "10.0.0.0/24", zones: []string{"a"}, newbits: 0, wantErr: true},
+		{cidr: "10.0.0.0/24", zones: []string{"a"}, newbits: 9, wantErr: true},
+		{cidr: "10.0.0.0/24", zones: []string{"a", "a"}, newbits: 2, wantErr: true},
+		{cidr: "10.0.0.0", zones: []string{"a"}, newbits: 2, wantErr: true},
+	}
+
+	for _, tc := range cases {
+		got, err := cidrSubnetsForAZ(tc.cidr, tc.zones, tc.newbits)
+		if tc.wantErr {
+			if err == nil {
+				t.Errorf("cidrSubnetsForAZ(%q, %q, %d): expected error, got %v", tc.cidr, tc.zones, tc.newbits, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("cidrSubnetsForAZ(%q, %q, %d): unexpected error: %s", tc.cidr, tc.zones, tc.newbits, err)
+			continue
+		}
+		if !maps.Equal(got, tc.want) {
+			t.Errorf("cidrSubnetsForAZ(%q, %q, %d) = %v, want %v", tc.cidr, tc.zones, tc.newbits, got, tc.want)
+		}
+	}
+}
+
+func TestCIDRSubnetsForAZFunctionRun(t *testing.T) {
+	req := function.RunRequest{
+		Arguments: function.NewArgumentsData([]attr.Value{
+			types.StringValue("192.168.0.0/22"),
+			types.ListValueMust(types.StringType, []attr.Value{types.StringValue("az-a"), types.StringValue("az-b")}),
+			types.Int64Value(2),
+		}),
+	}
+	resp := &function.RunResponse{
+		Result: function.NewResultData(types.MapUnknown(types.StringType)),
+	}
+
+	NewCIDRSubnetsForAZFunction().Run(context.Background(), req, resp)
+	if resp.Error != nil {
+		t.Fatalf("unexpected error: %s", resp.Error)
+	}
+
+	want := types.MapValueMust(types.StringType, map[string]attr.Value{
+		"az-a": types.StringValue("192.168.0.0/24"),
+		"az-b": types.StringValue("192.168.1.0/24"),
+	})
+	if got := resp.Result.Value(); !got.Equal(want) {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,13 @@
+// Package fwfunctions holds the provider-defined functions, called from HCL as
+// provider::acecloud::<name>.
+package fwfunctions
+
+import "github.com/hashicorp/terraform-plugin-framework/function"
+
+// Functions returns every provider-defined function.
+func Functions() []func() function.Function {
+	return []func() function.Function{
+		NewParseInstanceIDFunction,
+		NewCIDRSubnetsForAZFunction,
+	}
+}
@@ -0,0 +1,85 @@
+package fwfunctions
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/helpers"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// NewParseInstanceIDFunction returns provider::acecloud::parse_instance_id.
+func NewParseInstanceIDFunction() function.Function {
+	return &parseInstanceIDFunction{}
+}
+
+type parseInstanceIDFunction struct{}
+
+// instanceID is a VM instance ID, optionally qualified with the region and
+// project it lives in as "region/project_id/instance_id".
+type instanceID struct {
+	Region     string `tfsdk:"region"`
+	ProjectID  string `tfsdk:"project_id"`
+	InstanceID string `tfsdk:"instance_id"`
+}
+
+var instanceIDAttrTypes = map[string]attr.Type{
+	"region":      types.StringType,
+	"project_id":  types.StringType,
+	"instance_id": types.StringType,
+}
+
+func (f *parseInstanceIDFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_instance_id"
+}
+
+func (f *parseInstanceIDFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Split a VM instance ID into its parts",
+		Description: "Parses an instance ID of the form \"region/project_id/instance_id\", or a bare instance ID, " +
+			"into an object with region, project_id and instance_id. Parts missing from a bare ID are empty strings.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "id",
+				Description: "The instance ID to parse",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: instanceIDAttrTypes,
+		},
+	}
+}
+
+func (f *parseInstanceIDFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var id string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &id))
+	if resp.Error != nil {
+		return
+	}
+
+	parsed, err := parseInstanceID(id)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, parsed))
+}
+
+func parseInstanceID(id string) (instanceID, error) {
+	if id == "" {
+		return instanceID{}, fmt.Errorf("instance ID must not be empty")
+	}
+	if !strings.Contains(id, "/") {
+		return instanceID{InstanceID: id}, nil
+	}
+
+	parts, err := helpers.ParseCompositeID(id, 3)
+	if err != nil {
+		return instanceID{}, err
+	}
+	return instanceID{Region: parts[0], ProjectID: parts[1], InstanceID: parts[2]}, nil
+}
@@ -0,0 +1,65 @@
+package fwfunctions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestParseInstanceID(t *testing.T) {
+	cases := []struct {
+		id      string
+		want    instanceID
+		wantErr bool
+	}{
+		{id: "4f1c2a", want: instanceID{InstanceID: "4f1c2a"}},
+		{id: "ap-south-mum-1/1234/4f1c2a", want: instanceID{Region: "ap-south-mum-1", ProjectID: "1234", InstanceID: "4f1c2a"}},
+		{id: "", wantErr: true},
+		{id: "ap-south-mum-1/4f1c2a", wantErr: true},
+		{id: "ap-south-mum-1//4f1c2a", wantErr: true},
+		{id: "a/b/c/d", wantErr: true},
+	}
+
+	for _, tc := range cases {
+		got, err := parseInstanceID(tc.id)
+		if tc.wantErr {
+			if err == nil {
+				t.Errorf("parseInstanceID(%q): expected error, got %+v", tc.id, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseInstanceID(%q): unexpected error: %s", tc.id, err)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("parseInstanceID(%q) = %+v, want %+v", tc.id, got, tc.want)
+		}
+	}
+}
+
+func TestParseInstanceIDFunctionRun(t *testing.T) {
+	req := function.RunRequest{
+		Arguments: function.NewArgumentsData([]attr.Value{types.StringValue("r1/p1/i1")}),
+	}
+	resp := &function.RunResponse{
+		Result: function.NewResultData(types.ObjectUnknown(instanceIDAttrTypes)),
+	}
+
+	NewParseInstanceIDFunction().Run(context.Background(), req, resp)
+	if resp.Error != nil {
+		t.Fatalf("unexpected error: %s", resp.Error)
+	}
+
+	want := types.ObjectValueMust(instanceIDAttrTypes, map[string]attr.Value{
+		"region":      types.StringValue("r1"),
+		"project_id":  types.StringValue("p1"),
+		"instance_id": types.StringValue("i1"),
+	})
+	if got := resp.Result.Value(); !got.Equal(want) {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
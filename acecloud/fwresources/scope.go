@@ -0,0 +1,41 @@
+package fwresources
+
+import (
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// regionAttribute is the framework counterpart of helpers.RegionSchema.
+func regionAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Optional:    true,
+		Computed:    true,
+		Description: "Region the resource is managed in. Defaults to the provider region",
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.UseStateForUnknown(),
+			stringplanmodifier.RequiresReplace(),
+		},
+	}
+}
+
+// projectIDAttribute is the framework counterpart of helpers.ProjectIDSchema.
+func projectIDAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Optional:    true,
+		Computed:    true,
+		Description: "Project the resource is managed in. Defaults to the provider project_id",
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.UseStateForUnknown(),
+			stringplanmodifier.RequiresReplace(),
+		},
+	}
+}
+
+// scopedClient is the framework counterpart of helpers.ScopedClient. Null and
+// unknown values keep the provider defaults.
+func scopedClient(c *client.AceCloudClient, region, projectID types.String) *client.AceCloudClient {
+	return c.WithScope(region.ValueString(), projectID.ValueString())
+}
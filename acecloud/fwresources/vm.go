@@ -0,0 +1,508 @@
+package fwresources
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/client"
+	apitypes "github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/client/types"
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/helpers"
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/resources"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// NewVMResource returns the framework implementation of acecloud_vm. It is
+// state-compatible with resources.ResourceAceCloudVM: the schema version,
+// attribute names and types are the same, so switching implementations needs
+// no state migration.
+func NewVMResource() resource.Resource {
+	return &vmResource{}
+}
+
+type vmResource struct {
+	client *client.AceCloudClient
+}
+
+type vmResourceModel struct {
+	ID                  types.String    `tfsdk:"id"`
+	Name                types.String    `tfsdk:"name"`
+	Flavor              types.String    `tfsdk:"flavor"`
+	BootUUID            types.String    `tfsdk:"boot_uuid"`
+	DeleteOnTermination types.Bool      `tfsdk:"delete_on_termination"`
+	Network             types.List      `tfsdk:"network"`
+	Key                 types.String    `tfsdk:"key"`
+	SecurityGroup       types.List      `tfsdk:"security_group"`
+	SourceType          types.String    `tfsdk:"source_type"`
+	AvailabilityZone    types.String    `tfsdk:"availability_zone"`
+	BillingType         types.String    `tfsdk:"billing_type"`
+	Volumes             []vmVolumeModel `tfsdk:"volumes"`
+	VMCount             types.Int64     `tfsdk:"vm_count"`
+	InstanceID          types.String    `tfsdk:"instance_id"`
+	Status              types.String    `tfsdk:"status"`
+	IPAddress           types.String    `tfsdk:"ip_address"`
+	Region              types.String    `tfsdk:"region"`
+	ProjectID           types.String    `tfsdk:"project_id"`
+	Timeouts            timeouts.Value  `tfsdk:"timeouts"`
+}
+
+// vmIdentityModel is the resource identity of acecloud_vm, shared with the
+// SDK implementation and the list resource.
+type vmIdentityModel struct {
+	ID        types.String `tfsdk:"id"`
+	Region    types.String `tfsdk:"region"`
+	ProjectID types.String `tfsdk:"project_id"`
+}
+
+func newVMIdentityModel(id string, c *client.AceCloudClient) vmIdentityModel {
+	return vmIdentityModel{
+		ID:        types.StringValue(id),
+		Region:    types.StringValue(c.Region),
+		ProjectID: types.StringValue(c.ProjectID),
+	}
+}
+
+type vmVolumeModel struct {
+	Boot        types.Bool   `tfsdk:"boot"`
+	VolumeType  types.String `tfsdk:"volume_type"`
+	Size        types.Int64  `tfsdk:"size"`
+	BillingType types.String `tfsdk:"billing_type"`
+}
+
+// setFromAPI records the attributes the API returns. The boot image,
+// networks, security groups, volumes and the other create options are not
+// returned and are left as they are.
+func (m *vmResourceModel) setFromAPI(vm *apitypes.VM, c *client.AceCloudClient) {
+	m.ID = types.StringValue(vm.ID)
+	m.Name = types.StringValue(vm.Name)
+	m.Flavor = types.StringValue(vm.Flavor)
+	m.Key = types.StringValue(vm.Key)
+	m.AvailabilityZone = types.StringValue(vm.AvailabilityZone)
+	m.InstanceID = types.StringValue(vm.ID)
+	m.Status = types.StringValue(vm.Status)
+	m.IPAddress = types.StringValue(vm.PublicIP())
+	m.Region = types.StringValue(c.Region)
+	m.ProjectID = types.StringValue(c.ProjectID)
+}
+
+// setVMImportDefaults assumes the defaults for the create options the API
+// does not return, as the SDK importer does.
+func setVMImportDefaults(ctx context.Context, state *tfsdk.State) diag.Diagnostics {
+	var diags diag.Diagnostics
+	diags.Append(state.SetAttribute(ctx, path.Root("delete_on_termination"), true)...)
+	diags.Append(state.SetAttribute(ctx, path.Root("source_type"), "image")...)
+	diags.Append(state.SetAttribute(ctx, path.Root("billing_type"), "hourly")...)
+	diags.Append(state.SetAttribute(ctx, path.Root("vm_count"), int64(1))...)
+	return diags
+}
+
+var (
+	_ resource.Resource                = &vmResource{}
+	_ resource.ResourceWithConfigure   = &vmResource{}
+	_ resource.ResourceWithIdentity    = &vmResource{}
+	_ resource.ResourceWithImportState = &vmResource{}
+)
+
+func (r *vmResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_vm"
+}
+
+func (r *vmResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of the virtual machine instance",
+			},
+			"flavor": schema.StringAttribute{
+				Required:    true,
+				Description: "Flavor ID for the VM instance",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"boot_uuid": schema.StringAttribute{
+				Required:    true,
+				Description: "Boot image UUID",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"delete_on_termination": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+				Description: "Whether to delete volumes on VM termination",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"network": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "List of network IDs to attach",
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+			},
+			"key": schema.StringAttribute{
+				Required:    true,
+				Description: "SSH key for accessing the VM",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"security_group": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "List of security group IDs to apply",
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+			},
+			"source_type": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("image"),
+				Description: "Source type for boot device",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"availability_zone": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("nova"),
+				Description: "Availability zone for the VM",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"billing_type": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("hourly"),
+				Description: "Billing type for the VM",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"vm_count": schema.Int64Attribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(1),
+				Description: "Number of VM instances to create",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"instance_id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The created VM instance ID",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"status": schema.StringAttribute{
+				Computed:    true,
+				Description: "Current status of the VM instance",
+			},
+			"ip_address": schema.StringAttribute{
+				Computed:    true,
+				Description: "IP address of the VM instance",
+			},
+			"region":     regionAttribute(),
+			"project_id": projectIDAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Delete: true,
+			}),
+			"volumes": schema.ListNestedBlock{
+				Description: "List of volumes to attach",
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"boot": schema.BoolAttribute{
+							Optional:    true,
+							Computed:    true,
+							Default:     booldefault.StaticBool(false),
+							Description: "Whether this is the boot volume",
+						},
+						"volume_type": schema.StringAttribute{
+							Required:    true,
+							Description: "Type of the volume",
+						},
+						"size": schema.Int64Attribute{
+							Required:    true,
+							Description: "Size of the volume in GB",
+						},
+						"billing_type": schema.StringAttribute{
+							Optional:    true,
+							Computed:    true,
+							Default:     stringdefault.StaticString("hourly"),
+							Description: "Billing type for the volume",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *vmResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"id": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "ID of the VM instance",
+			},
+			"region": identityschema.StringAttribute{
+				OptionalForImport: true,
+				Description:       "Region of the VM instance",
+			},
+			"project_id": identityschema.StringAttribute{
+				OptionalForImport: true,
+				Description:       "Project of the VM instance",
+			},
+		},
+	}
+}
+
+func (r *vmResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	c, ok := req.ProviderData.(*client.AceCloudClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected provider data",
+			fmt.Sprintf("Expected *client.AceCloudClient, got %T. This is a bug in the provider; please report it.", req.ProviderData),
+		)
+		return
+	}
+	r.client = c
+}
+
+func (r *vmResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan vmResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	c := scopedClient(r.client, plan.Region, plan.ProjectID)
+
+	vmReq := &apitypes.VMCreateRequest{
+		Name:                plan.Name.ValueString(),
+		Flavor:              plan.Flavor.ValueString(),
+		BootUUID:            plan.BootUUID.ValueString(),
+		DeleteOnTermination: plan.DeleteOnTermination.ValueBool(),
+		SourceType:          plan.SourceType.ValueString(),
+		AvailabilityZone:    plan.AvailabilityZone.ValueString(),
+		BillingType:         plan.BillingType.ValueString(),
+		Key:                 plan.Key.ValueString(),
+		Count:               int(plan.VMCount.ValueInt64()),
+	}
+	resp.Diagnostics.Append(plan.Network.ElementsAs(ctx, &vmReq.Networks, false)...)
+	resp.Diagnostics.Append(plan.SecurityGroup.ElementsAs(ctx, &vmReq.SecurityGroups, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	for _, v := range plan.Volumes {
+		vmReq.Volumes = append(vmReq.Volumes, apitypes.VolumeRequest{
+			Boot:        v.Boot.ValueBool(),
+			VolumeType:  v.VolumeType.ValueString(),
+			Size:        int(v.Size.ValueInt64()),
+			BillingType: v.BillingType.ValueString(),
+		})
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, 20*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	vm, err := c.CreateVM(ctx, vmReq)
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating VM", err.Error())
+		return
+	}
+
+	// Save the ID first so that a failed wait leaves the VM tainted in
+	// state rather than orphaned.
+	plan.ID = types.StringValue(vm.ID)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), plan.ID)...)
+
+	if err := resources.WaitForVM(ctx, c, vm.ID, createTimeout); err != nil {
+		resp.Diagnostics.AddError("Error creating VM", fmt.Sprintf("error waiting for VM (%s) to be created: %s", vm.ID, err))
+		return
+	}
+	if vm, err = c.GetVM(ctx, vm.ID); err != nil {
+		resp.Diagnostics.AddError("Error reading VM", err.Error())
+		return
+	}
+
+	plan.ID = types.StringValue(vm.ID)
+	plan.InstanceID = types.StringValue(vm.ID)
+	plan.Status = types.StringValue(vm.Status)
+	plan.IPAddress = types.StringValue(vm.PublicIP())
+	plan.Region = types.StringValue(c.Region)
+	plan.ProjectID = types.StringValue(c.ProjectID)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, newVMIdentityModel(vm.ID, c))...)
+}
+
+func (r *vmResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state vmResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	c := scopedClient(r.client, state.Region, state.ProjectID)
+
+	vm, err := c.GetVM(ctx, state.ID.ValueString())
+	if err != nil {
+		if helpers.IsNotFoundError(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Error reading VM", err.Error())
+		return
+	}
+
+	state.setFromAPI(vm, c)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, newVMIdentityModel(vm.ID, c))...)
+}
+
+func (r *vmResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state vmResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	c := scopedClient(r.client, state.Region, state.ProjectID)
+
+	// Only the name can be changed in place. Every other argument requires
+	// replacement, so the rest of the state is what the API returns.
+	var vm *apitypes.VM
+	var err error
+	if !plan.Name.Equal(state.Name) {
+		vm, err = c.UpdateVM(ctx, state.ID.ValueString(), &apitypes.VMUpdateRequest{
+			Name: plan.Name.ValueString(),
+		})
+	} else {
+		vm, err = c.GetVM(ctx, state.ID.ValueString())
+	}
+	if err != nil {
+		if helpers.IsNotFoundError(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Error updating VM", err.Error())
+		return
+	}
+
+	state.setFromAPI(vm, c)
+	state.Timeouts = plan.Timeouts
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, newVMIdentityModel(vm.ID, c))...)
+}
+
+// ImportState accepts the same forms as the SDK implementation: a bare
+// instance ID, an ID qualified as region/project_id/instance_id, or a resource
+// identity.
+func (r *vmResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	var identity vmIdentityModel
+	switch {
+	case req.ID == "":
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	case strings.Contains(req.ID, "/"):
+		parts, err := helpers.ParseCompositeID(req.ID, 3)
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid import ID", err.Error())
+			return
+		}
+		identity = vmIdentityModel{
+			ID:        types.StringValue(parts[2]),
+			Region:    types.StringValue(parts[0]),
+			ProjectID: types.StringValue(parts[1]),
+		}
+	default:
+		identity.ID = types.StringValue(req.ID)
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), identity.ID)...)
+	resp.Diagnostics.Append(setVMImportDefaults(ctx, &resp.State)...)
+	if identity.Region.ValueString() != "" {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("region"), identity.Region)...)
+	}
+	if identity.ProjectID.ValueString() != "" {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_id"), identity.ProjectID)...)
+	}
+}
+
+func (r *vmResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state vmResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	c := scopedClient(r.client, state.Region, state.ProjectID)
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, 20*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := c.DeleteVMs(ctx, []string{state.ID.ValueString()})
+	if err != nil {
+		if !helpers.IsNotFoundError(err) {
+			resp.Diagnostics.AddError("Error deleting VM", err.Error())
+		}
+		return
+	}
+
+	if err := resources.WaitForVMDeleted(ctx, c, state.ID.ValueString(), deleteTimeout); err != nil {
+		resp.Diagnostics.AddError("Error deleting VM", fmt.Sprintf("error waiting for VM (%s) to be deleted: %s", state.ID.ValueString(), err))
+	}
+}
@@ -0,0 +1,264 @@
+package fwresources
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/client"
+	apitypes "github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/client/types"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+// NewVMListResource returns the list resource for acecloud_vm, which lets
+// `terraform query` enumerate existing instances and generate import blocks
+// and configuration for them.
+//
+// While acecloud_vm is served by the SDK provider, the framework cannot see its
+// schemas, so they are passed in as resourceSchema and identitySchema. Both
+// are nil when the framework implementation of acecloud_vm is registered.
+func NewVMListResource(resourceSchema *tfprotov6.Schema, identitySchema *tfprotov6.ResourceIdentitySchema) func() list.ListResource {
+	return func() list.ListResource {
+		return &vmListResource{
+			resourceSchema: resourceSchema,
+			identitySchema: identitySchema,
+		}
+	}
+}
+
+type vmListResource struct {
+	client *client.AceCloudClient
+
+	resourceSchema *tfprotov6.Schema
+	identitySchema *tfprotov6.ResourceIdentitySchema
+}
+
+type vmListConfigModel struct {
+	NameRegex        types.String `tfsdk:"name_regex"`
+	Status           types.String `tfsdk:"status"`
+	AvailabilityZone types.String `tfsdk:"availability_zone"`
+	Flavor           types.String `tfsdk:"flavor"`
+	Tags             types.List   `tfsdk:"tags"`
+	Region           types.String `tfsdk:"region"`
+	ProjectID        types.String `tfsdk:"project_id"`
+}
+
+var (
+	_ list.ListResourceWithConfigure      = &vmListResource{}
+	_ list.ListResourceWithRawV6Schemas   = &vmListResource{}
+	_ list.ListResourceWithValidateConfig = &vmListResource{}
+)
+
+func (r *vmListResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_vm"
+}
+
+func (r *vmListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"name_regex": schema.StringAttribute{
+				Optional:    true,
+				Description: "Regular expression the VM name must match",
+			},
+			"status": schema.StringAttribute{
+				Optional:    true,
+				Description: "Status of the VMs to list",
+			},
+			"availability_zone": schema.StringAttribute{
+				Optional:    true,
+				Description: "Availability zone of the VMs to list",
+			},
+			"flavor": schema.StringAttribute{
+				Optional:    true,
+				Description: "Flavor of the VMs to list",
+			},
+			"tags": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Tags the VMs must carry. All listed tags must be present",
+			},
+			"region": schema.StringAttribute{
+				Optional:    true,
+				Description: "Region to list VMs in. Defaults to the provider region",
+			},
+			"project_id": schema.StringAttribute{
+				Optional:    true,
+				Description: "Project to list VMs in. Defaults to the provider project_id",
+			},
+		},
+	}
+}
+
+// RawV6Schemas supplies the schemas of the SDK implementation of acecloud_vm.
+// With no schemas set the framework uses its own acecloud_vm resource.
+func (r *vmListResource) RawV6Schemas(ctx context.Context, req list.RawV6SchemaRequest, resp *list.RawV6SchemaResponse) {
+	resp.ProtoV6Schema = r.resourceSchema
+	resp.ProtoV6IdentitySchema = r.identitySchema
+}
+
+func (r *vmListResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	c, ok := req.ProviderData.(*client.AceCloudClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected provider data",
+			fmt.Sprintf("Expected *client.AceCloudClient, got %T. This is a bug in the provider; please report it.", req.ProviderData),
+		)
+		return
+	}
+	r.client = c
+}
+
+func (r *vmListResource) ValidateListResourceConfig(ctx context.Context, req list.ValidateConfigRequest, resp *list.ValidateConfigResponse) {
+	var config vmListConfigModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() || config.NameRegex.IsNull() || config.NameRegex.IsUnknown() {
+		return
+	}
+
+	if _, err := regexp.Compile(config.NameRegex.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("name_regex"), "Invalid name_regex", err.Error())
+	}
+}
+
+// List streams the matching VMs page by page, so that Terraform can stop early
+// once it has req.Limit results. status, availability_zone and flavor are also
+// sent to the API to narrow the pages; every filter is applied to each page.
+func (r *vmListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var config vmListConfigModel
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	c := scopedClient(r.client, config.Region, config.ProjectID)
+
+	match, diags := vmListMatcher(ctx, config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	filters := url.Values{}
+	for key, v := range map[string]types.String{
+		"status":            config.Status,
+		"availability_zone": config.AvailabilityZone,
+		"flavor":            config.Flavor,
+	} {
+		if v.ValueString() != "" {
+			filters.Set(key, v.ValueString())
+		}
+	}
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		var count int64
+		for vm, err := range c.VMs(ctx, &client.ListOptions{Filters: filters}) {
+			if err != nil {
+				var diags diag.Diagnostics
+				diags.AddError("Error listing VMs", err.Error())
+				push(list.ListResult{Diagnostics: diags})
+				return
+			}
+			if !match(vm) {
+				continue
+			}
+
+			if !push(r.listResult(ctx, req, c, vm)) {
+				return
+			}
+			count++
+			if req.Limit > 0 && count >= req.Limit {
+				return
+			}
+		}
+	}
+}
+
+func (r *vmListResource) listResult(ctx context.Context, req list.ListRequest, c *client.AceCloudClient, vm apitypes.VM) list.ListResult {
+	result := req.NewListResult(ctx)
+	result.DisplayName = vm.Name
+
+	result.Diagnostics.Append(result.Identity.Set(ctx, newVMIdentityModel(vm.ID, c))...)
+	if !req.IncludeResource {
+		return result
+	}
+
+	// The API does not return the boot image, networks, security groups or
+	// volumes of an instance; they are left null for the practitioner to
+	// fill in. The other create options have their defaults, as on import.
+	model := vmResourceModel{
+		BootUUID:            types.StringNull(),
+		DeleteOnTermination: types.BoolValue(true),
+		Network:             types.ListNull(types.StringType),
+		SecurityGroup:       types.ListNull(types.StringType),
+		SourceType:          types.StringValue("image"),
+		BillingType:         types.StringValue("hourly"),
+		VMCount:             types.Int64Value(1),
+		Timeouts: timeouts.Value{
+			Object: types.ObjectNull(map[string]attr.Type{
+				"create": types.StringType,
+				"delete": types.StringType,
+			}),
+		},
+	}
+	model.setFromAPI(&vm, c)
+	result.Diagnostics.Append(result.Resource.Set(ctx, &model)...)
+
+	return result
+}
+
+// vmListMatcher returns the client-side part of the list filter.
+func vmListMatcher(ctx context.Context, config vmListConfigModel) (func(apitypes.VM) bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	var nameRegex *regexp.Regexp
+	if v := config.NameRegex.ValueString(); v != "" {
+		re, err := regexp.Compile(v)
+		if err != nil {
+			diags.AddAttributeError(path.Root("name_regex"), "Invalid name_regex", err.Error())
+			return nil, diags
+		}
+		nameRegex = re
+	}
+
+	var tags []string
+	diags.Append(config.Tags.ElementsAs(ctx, &tags, false)...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	return func(vm apitypes.VM) bool {
+		if v := config.Status.ValueString(); v != "" && vm.Status != v {
+			return false
+		}
+		if v := config.AvailabilityZone.ValueString(); v != "" && vm.AvailabilityZone != v {
+			return false
+		}
+		if v := config.Flavor.ValueString(); v != "" && vm.Flavor != v {
+			return false
+		}
+		if nameRegex != nil && !nameRegex.MatchString(vm.Name) {
+			return false
+		}
+		for _, tag := range tags {
+			if !slices.Contains(vm.Tags, tag) {
+				return false
+			}
+		}
+		return true
+	}, diags
+}
@@ -0,0 +1,305 @@
+package fwresources_test
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud"
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/client/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// instancesAPI answers the instance list call with offset paging and the
+// status and flavor filters the API applies, and records the queries it got.
+type instancesAPI struct {
+	vms     []types.VM
+	queries []url.Values
+}
+
+func (a *instancesAPI) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if req.Method != http.MethodGet || req.URL.Path != "/cloud/instances" {
+		w.WriteHeader(http.StatusNotFound)
+		_ = json.NewEncoder(w).Encode(map[string]any{"error": true, "message": "not found"})
+		return
+	}
+	q := req.URL.Query()
+	a.queries = append(a.queries, q)
+
+	var matched []types.VM
+	for _, vm := range a.vms {
+		if (!q.Has("status") || vm.Status == q.Get("status")) && (!q.Has("flavor") || vm.Flavor == q.Get("flavor")) {
+			matched = append(matched, vm)
+		}
+	}
+	offset, _ := strconv.Atoi(q.Get("offset"))
+	limit, _ := strconv.Atoi(q.Get("limit"))
+	page := matched[min(offset, len(matched)):min(offset+limit, len(matched))]
+	_ = json.NewEncoder(w).Encode(map[string]any{"data": page})
+}
+
+// listServer is the muxed provider, configured against instancesAPI.
+type listServer struct {
+	tfprotov6.ProviderServerWithListResource
+	api     *instancesAPI
+	schemas *tfprotov6.GetProviderSchemaResponse
+}
+
+// newListServer starts the provider with acecloud_vm served by the SDK or,
+// with frameworkVM, by the framework.
+func newListServer(t *testing.T, frameworkVM bool, vms ...types.VM) *listServer {
+	t.Helper()
+	ctx := context.Background()
+	t.Setenv(acecloud.FrameworkVMEnvVar, "")
+	if frameworkVM {
+		t.Setenv(acecloud.FrameworkVMEnvVar, "1")
+	}
+
+	api := &instancesAPI{vms: vms}
+	srv := httptest.NewTLSServer(api)
+	t.Cleanup(srv.Close)
+
+	factory, err := acecloud.ProtoV6ProviderServerFactory(ctx, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server, ok := factory().(tfprotov6.ProviderServerWithListResource)
+	if !ok {
+		t.Fatal("provider server does not serve list resources")
+	}
+	s := &listServer{ProviderServerWithListResource: server, api: api}
+
+	s.schemas, err = s.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkDiags(t, "GetProviderSchema", s.schemas.Diagnostics)
+
+	config := map[string]tftypes.Value{
+		"api_endpoint":                tftypes.NewValue(tftypes.String, srv.URL),
+		"ca_cert_pem":                 tftypes.NewValue(tftypes.String, string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw}))),
+		"api_key":                     tftypes.NewValue(tftypes.String, "test-api-key"),
+		"region":                      tftypes.NewValue(tftypes.String, "test-region"),
+		"project_id":                  tftypes.NewValue(tftypes.String, "test-project"),
+		"skip_credentials_validation": tftypes.NewValue(tftypes.Bool, true),
+		"list_page_size":              tftypes.NewValue(tftypes.Number, 2),
+	}
+	resp, err := s.ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{
+		Config: dynamicValue(t, s.schemas.Provider.ValueType(), config),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkDiags(t, "ConfigureProvider", resp.Diagnostics)
+	return s
+}
+
+// dynamicValue encodes attrs as an object of typ, with every other attribute
+// null.
+func dynamicValue(t *testing.T, typ tftypes.Type, attrs map[string]tftypes.Value) *tfprotov6.DynamicValue {
+	t.Helper()
+	vals := map[string]tftypes.Value{}
+	for name, attrType := range typ.(tftypes.Object).AttributeTypes {
+		vals[name] = tftypes.NewValue(attrType, nil)
+	}
+	for name, v := range attrs {
+		vals[name] = v
+	}
+	dv, err := tfprotov6.NewDynamicValue(typ, tftypes.NewValue(typ, vals))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &dv
+}
+
+func checkDiags(t *testing.T, what string, diags []*tfprotov6.Diagnostic) {
+	t.Helper()
+	for _, d := range diags {
+		if d.Severity == tfprotov6.DiagnosticSeverityError {
+			t.Fatalf("%s: %s: %s", what, d.Summary, d.Detail)
+		}
+	}
+}
+
+// list runs acecloud_vm's list resource with config.
+func (s *listServer) list(t *testing.T, config map[string]tftypes.Value, includeResource bool, limit int64) []tfprotov6.ListResourceResult {
+	t.Helper()
+	stream, err := s.ListResource(context.Background(), &tfprotov6.ListResourceRequest{
+		TypeName:        "acecloud_vm",
+		Config:          dynamicValue(t, s.schemas.ListResourceSchemas["acecloud_vm"].ValueType(), config),
+		IncludeResource: includeResource,
+		Limit:           limit,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var results []tfprotov6.ListResourceResult
+	for result := range stream.Results {
+		checkDiags(t, "ListResource", result.Diagnostics)
+		results = append(results, result)
+	}
+	return results
+}
+
+func displayNames(results []tfprotov6.ListResourceResult) []string {
+	var names []string
+	for _, r := range results {
+		names = append(names, r.DisplayName)
+	}
+	return names
+}
+
+func forEachVMImplementation(t *testing.T, vms []types.VM, test func(t *testing.T, s *listServer)) {
+	for name, frameworkVM := range map[string]bool{"sdk": false, "framework": true} {
+		t.Run(name, func(t *testing.T) {
+			test(t, newListServer(t, frameworkVM, vms...))
+		})
+	}
+}
+
+func TestVMListFilters(t *testing.T) {
+	vms := []types.VM{
+		{ID: "vm-1", Name: "web-1", Status: "ACTIVE", Flavor: "C.2x4", Tags: []string{"prod", "web"}},
+		{ID: "vm-2", Name: "web-2", Status: "ACTIVE", Flavor: "C.2x4", Tags: []string{"staging"}},
+		{ID: "vm-3", Name: "web-3", Status: "ACTIVE", Flavor: "C.4x8", Tags: []string{"prod"}},
+		{ID: "vm-4", Name: "db-1", Status: "ACTIVE", Flavor: "C.2x4", Tags: []string{"prod"}},
+		{ID: "vm-5", Name: "web-4", Status: "SHUTOFF", Flavor: "C.2x4", Tags: []string{"prod"}},
+		{ID: "vm-6", Name: "web-5", Status: "ACTIVE", Flavor: "C.2x4", Tags: []string{"prod"}},
+	}
+	forEachVMImplementation(t, vms, func(t *testing.T, s *listServer) {
+		results := s.list(t, map[string]tftypes.Value{
+			"name_regex": tftypes.NewValue(tftypes.String, "^web-"),
+			"status":     tftypes.NewValue(tftypes.String, "ACTIVE"),
+			"flavor":     tftypes.NewValue(tftypes.String, "C.2x4"),
+			"tags":       tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{tftypes.NewValue(tftypes.String, "prod")}),
+		}, false, 0)
+		if got, want := displayNames(results), []string{"web-1", "web-5"}; !slices.Equal(got, want) {
+			t.Errorf("listed %v, want %v", got, want)
+		}
+
+		// status and flavor narrow the pages on the server; name_regex and
+		// tags are only applied by the provider.
+		if len(s.api.queries) == 0 {
+			t.Fatal("no instances were requested")
+		}
+		for _, q := range s.api.queries {
+			if q.Get("status") != "ACTIVE" || q.Get("flavor") != "C.2x4" || q.Has("availability_zone") || q.Has("name_regex") || q.Has("tags") {
+				t.Errorf("requested instances with %s", q.Encode())
+			}
+		}
+	})
+}
+
+func TestVMListLimit(t *testing.T) {
+	var vms []types.VM
+	for _, id := range []string{"vm-a", "vm-b", "vm-c", "vm-d", "vm-e"} {
+		vms = append(vms, types.VM{ID: id, Name: id})
+	}
+	forEachVMImplementation(t, vms, func(t *testing.T, s *listServer) {
+		if got := s.list(t, nil, false, 0); len(got) != 5 {
+			t.Errorf("listed %d VMs without a limit, want 5", len(got))
+		}
+		pagesWithoutLimit := len(s.api.queries)
+
+		results := s.list(t, nil, false, 2)
+		if len(results) != 2 {
+			t.Errorf("listed %d VMs with limit 2, want 2", len(results))
+		}
+		// The provider pages two at a time, so the first page is enough.
+		if pages := len(s.api.queries) - pagesWithoutLimit; pages != 1 {
+			t.Errorf("fetched %d pages for limit 2, want 1", pages)
+		}
+	})
+}
+
+// Results carry the identity and, when asked for, the resource in the schema
+// of whichever implementation serves acecloud_vm. While that is the SDK, the
+// list resource only knows its raw protocol schema.
+func TestVMListResults(t *testing.T) {
+	vms := []types.VM{{ID: "vm-1", Name: "web-1", Status: "ACTIVE", Flavor: "C.2x4", Key: "deploy", AvailabilityZone: "nova"}}
+	forEachVMImplementation(t, vms, func(t *testing.T, s *listServer) {
+		identityType := identityValueType(t, s)
+		resourceType := s.schemas.ResourceSchemas["acecloud_vm"].ValueType()
+
+		results := s.list(t, nil, false, 0)
+		if len(results) != 1 {
+			t.Fatalf("listed %d VMs, want 1", len(results))
+		}
+		if results[0].Resource != nil {
+			t.Error("result carries the resource although it was not asked for")
+		}
+
+		results = s.list(t, nil, true, 0)
+		if len(results) != 1 {
+			t.Fatalf("listed %d VMs, want 1", len(results))
+		}
+		result := results[0]
+
+		identity, err := result.Identity.IdentityData.Unmarshal(identityType)
+		if err != nil {
+			t.Fatalf("identity does not match the identity schema: %s", err)
+		}
+		checkAttrs(t, "identity", identity, map[string]string{
+			"id":         "vm-1",
+			"region":     "test-region",
+			"project_id": "test-project",
+		})
+
+		if result.Resource == nil {
+			t.Fatal("result carries no resource")
+		}
+		resource, err := result.Resource.Unmarshal(resourceType)
+		if err != nil {
+			t.Fatalf("resource does not match the resource schema: %s", err)
+		}
+		checkAttrs(t, "resource", resource, map[string]string{
+			"id":                "vm-1",
+			"instance_id":       "vm-1",
+			"name":              "web-1",
+			"flavor":            "C.2x4",
+			"key":               "deploy",
+			"availability_zone": "nova",
+			"source_type":       "image",
+			"billing_type":      "hourly",
+			"status":            "ACTIVE",
+			"region":            "test-region",
+			"project_id":        "test-project",
+		})
+	})
+}
+
+func identityValueType(t *testing.T, s *listServer) tftypes.Type {
+	t.Helper()
+	resp, err := s.GetResourceIdentitySchemas(context.Background(), &tfprotov6.GetResourceIdentitySchemasRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkDiags(t, "GetResourceIdentitySchemas", resp.Diagnostics)
+	return resp.IdentitySchemas["acecloud_vm"].ValueType()
+}
+
+func checkAttrs(t *testing.T, what string, v tftypes.Value, want map[string]string) {
+	t.Helper()
+	var attrs map[string]tftypes.Value
+	if err := v.As(&attrs); err != nil {
+		t.Fatal(err)
+	}
+	for name, w := range want {
+		var got string
+		if err := attrs[name].As(&got); err != nil {
+			t.Errorf("%s %s: %s", what, name, err)
+			continue
+		}
+		if got != w {
+			t.Errorf("%s %s = %q, want %q", what, name, got, w)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+// Package acctest holds the shared setup of the provider's acceptance tests.
+//
+// Tests run against the endpoint in ACECLOUD_API_ENDPOINT when it is set,
+// with the provider configured from the environment as usual. Otherwise each
+// test starts its own fakeapi server, so that `make testacc` needs no account
+// or network access.
+package acctest
+
+import (
+	"context"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud"
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/client"
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/fakeapi"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+)
+
+// ResourcePrefix starts the name of every resource created by the acceptance
+// tests. The sweepers delete whatever carries it.
+const ResourcePrefix = "tf-acc"
+
+// RandomName returns a unique name for a test resource.
+func RandomName() string {
+	return sdkacctest.RandomWithPrefix(ResourcePrefix)
+}
+
+// Env is the API an acceptance test runs against.
+type Env struct {
+	// Fake is the fake API, or nil when the test runs against a real
+	// endpoint.
+	Fake *fakeapi.Server
+
+	client *client.AceCloudClient
+}
+
+// New returns the environment for an acceptance test. It starts a fake API
+// unless ACECLOUD_API_ENDPOINT is set, and does nothing else unless TF_ACC is
+// set, leaving resource.Test to skip the test.
+func New(t *testing.T) *Env {
+	t.Helper()
+	if os.Getenv("TF_ACC") == "" {
+		return &Env{}
+	}
+
+	if os.Getenv("ACECLOUD_API_ENDPOINT") != "" {
+		c, err := envClient(os.Getenv("ACECLOUD_REGION"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &Env{client: c}
+	}
+
+	s := fakeapi.New()
+	t.Cleanup(s.Close)
+
+	c := client.NewAceCloudClient(s.URL, fakeapi.APIKey, fakeapi.DefaultRegion, fakeapi.DefaultProjectID)
+	c.HTTPClient = s.Client()
+	return &Env{Fake: s, client: c}
+}
+
+// SkipUnlessFake skips tests that need to control the API, such as its
+// status transitions.
+func (e *Env) SkipUnlessFake(t *testing.T) {
+	t.Helper()
+	if os.Getenv("ACECLOUD_API_ENDPOINT") != "" {
+		t.Skip("requires the fake API; unset ACECLOUD_API_ENDPOINT to run it")
+	}
+}
+
+// PreCheck verifies that the settings for a real endpoint are present.
+func (e *Env) PreCheck(t *testing.T) {
+	t.Helper()
+	if e.Fake != nil {
+		return
+	}
+	for _, name := range []string{"ACECLOUD_API_KEY", "ACECLOUD_REGION", "ACECLOUD_PROJECT_ID"} {
+		if os.Getenv(name) == "" {
+			t.Fatalf("%s must be set for acceptance tests against ACECLOUD_API_ENDPOINT", name)
+		}
+	}
+}
+
+// Client returns an API client with the provider's settings, for test checks.
+func (e *Env) Client() *client.AceCloudClient {
+	return e.client
+}
+
+// ProviderConfig returns the provider block for test configurations. Against
+// a real endpoint it is empty and the provider reads the environment.
+func (e *Env) ProviderConfig() string {
+	if e.Fake == nil {
+		return ""
+	}
+	// The fake serves TLS with a self-signed certificate, which the provider
+	// is told to trust like a private CA.
+	caCert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: e.Fake.Certificate().Raw})
+	return fmt.Sprintf(`
+provider "acecloud" {
+  api_endpoint = %q
+  api_key      = %q
+  region       = %q
+  project_id   = %q
+  ca_cert_pem  = %q
+}
+`, e.Fake.URL, fakeapi.APIKey, fakeapi.DefaultRegion, fakeapi.DefaultProjectID, caCert)
+}
+
+// ProtoV6ProviderFactories returns the provider factories for resource.Test.
+func (e *Env) ProtoV6ProviderFactories() map[string]func() (tfprotov6.ProviderServer, error) {
+	return map[string]func() (tfprotov6.ProviderServer, error){
+		"acecloud": func() (tfprotov6.ProviderServer, error) {
+			factory, err := acecloud.ProtoV6ProviderServerFactoryFor(context.Background(), acecloud.Provider("test"), "test")
+			if err != nil {
+				return nil, err
+			}
+			return factory(), nil
+		},
+	}
+}
+
+// SweeperClient returns an API client for a sweeper run in region. Sweepers
+// only run against a real endpoint.
+func SweeperClient(region string) (*client.AceCloudClient, error) {
+	if os.Getenv("ACECLOUD_API_ENDPOINT") == "" {
+		return nil, fmt.Errorf("ACECLOUD_API_ENDPOINT must be set to run sweepers")
+	}
+	return envClient(region)
+}
+
+// IsSweepable reports whether a resource name was created by the acceptance
+// tests.
+func IsSweepable(name string) bool {
+	return strings.HasPrefix(name, ResourcePrefix+"-")
+}
+
+func envClient(region string) (*client.AceCloudClient, error) {
+	apiKey := os.Getenv("ACECLOUD_API_KEY")
+	if apiKey == "" {
+		return nil, fmt.Errorf("ACECLOUD_API_KEY must be set")
+	}
+	if region == "" {
+		return nil, fmt.Errorf("a region must be given, for example through ACECLOUD_REGION")
+	}
+	return client.NewAceCloudClient(os.Getenv("ACECLOUD_API_ENDPOINT"), apiKey, region, os.Getenv("ACECLOUD_PROJECT_ID")), nil
+}
@@ -0,0 +1,17 @@
+package client
+
+import (
+	"context"
+	"iter"
+
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/client/types"
+)
+
+// AvailabilityZones returns an iterator over the availability zones of the region.
+func (c *AceCloudClient) AvailabilityZones(ctx context.Context, opts *ListOptions) iter.Seq2[types.AvailabilityZone, error] {
+	return paginate[types.AvailabilityZone](ctx, c, ServiceCompute, "/availability-zones", "availability zones", opts)
+}
+
+func (c *AceCloudClient) ListAvailabilityZones(ctx context.Context, opts *ListOptions) ([]types.AvailabilityZone, error) {
+	return Collect(c.AvailabilityZones(ctx, opts))
+}
@@ -4,23 +4,42 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"iter"
 	"net/http"
 	"net/http/httputil"
 	"net/url"
+	"strconv"
 	"time"
-	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/client/types"
 
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/client/types"
+	"github.com/hashicorp/go-uuid"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 type AceCloudClient struct {
-	BaseURL    string
-	APIKey     string
-	Region     string
-	ProjectID  string
+	BaseURL   string
+	APIKey    string
+	Region    string
+	ProjectID string
+	// ClientID and UserID identify the tenant and user acting on the API.
+	// They are sent as headers when non-zero.
+	ClientID int
+	UserID   int
+	// PageSize is the number of items requested per page by list calls.
+	PageSize int
+	// Endpoints overrides the URL of individual services, keyed by
+	// Service.Key.
+	Endpoints  map[string]string
 	HTTPClient *http.Client
+	// UserAgent is sent with every request when set.
+	UserAgent string
+	// Limiter throttles requests and retries those the API answers with
+	// 429. It is shared with the copies made by WithScope. Nil sends every
+	// request at once and returns 429s as errors.
+	Limiter *Limiter
 }
 
 func NewAceCloudClient(baseURL, apiKey, region, projectID string) *AceCloudClient {
@@ -29,129 +48,230 @@ func NewAceCloudClient(baseURL, apiKey, region, projectID string) *AceCloudClien
 		APIKey:    apiKey,
 		Region:    region,
 		ProjectID: projectID,
+		PageSize:  DefaultPageSize,
 		HTTPClient: &http.Client{
-			Timeout: 30 * time.Second,
+			Timeout: DefaultRequestTimeout,
 		},
 	}
 }
 
-func (c *AceCloudClient) CreateVM(ctx context.Context, vmReq *types.VMCreateRequest) (*types.VMCreateResponse, error) {
-	endpoint := fmt.Sprintf("%s/cloud/instances", c.BaseURL)
-	tflog.Debug(ctx, fmt.Sprintf("Creating VM with endpoint: %s", endpoint))
+// WithScope returns a copy of c whose requests use the given region and
+// project_id. Empty values keep the client's own. The copy shares c's HTTP
+// client and limiter.
+func (c *AceCloudClient) WithScope(region, projectID string) *AceCloudClient {
+	if (region == "" || region == c.Region) && (projectID == "" || projectID == c.ProjectID) {
+		return c
+	}
+	scoped := *c
+	if region != "" {
+		scoped.Region = region
+	}
+	if projectID != "" {
+		scoped.ProjectID = projectID
+	}
+	return &scoped
+}
 
-	params := url.Values{}
-	params.Add("region", c.Region)
-	params.Add("project_id", c.ProjectID)
+// RequestIDHeader carries the ID the client gives each request, so that a
+// failed call can be found in the API's logs.
+const RequestIDHeader = "X-Request-ID"
 
-	fullURL := endpoint + "?" + params.Encode()
+// APIError is returned when the API answers with an HTTP error status or with
+// an envelope whose error flag is set. StatusCode is 0 in the latter case.
+type APIError struct {
+	StatusCode int
+	Message    string
+	// RequestID is the X-Request-ID of the request that failed.
+	RequestID string
 
-	req, err := c.newRequest(ctx, "POST", fullURL, vmReq)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
+	// retryAfter is the delay a 429 response asked for.
+	retryAfter time.Duration
+}
 
-	var createResp types.VMCreateResponse
-	if err := c.doRequest(req, &createResp); err != nil {
-		return nil, fmt.Errorf("failed to create VM: %w", err)
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("API error %d: %s", e.StatusCode, e.Message)
+	if e.StatusCode == 0 {
+		msg = fmt.Sprintf("API returned error: %s", e.Message)
 	}
-
-	if createResp.Error {
-		return nil, fmt.Errorf("API returned error: %s", createResp.Message)
+	if e.RequestID != "" {
+		msg += fmt.Sprintf(" (request ID: %s)", e.RequestID)
 	}
+	return msg
+}
 
-	return &createResp, nil
+// IsNotFound reports whether err is an APIError with status 404.
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
 }
 
-func (c *AceCloudClient) GetVM(ctx context.Context, id string) (*types.VMGetResponse, error) {
-	endpoint := fmt.Sprintf("%s/cloud/instances/%s", c.BaseURL, id)
-	tflog.Debug(ctx, fmt.Sprintf("Getting VM with endpoint: %s", endpoint))
+// IsAuthError reports whether err is an APIError with status 401 or 403.
+func IsAuthError(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) &&
+		(apiErr.StatusCode == http.StatusUnauthorized || apiErr.StatusCode == http.StatusForbidden)
+}
 
-	params := url.Values{}
-	params.Add("region", c.Region)
-	params.Add("project_id", c.ProjectID)
+// envelope is the wrapper every AceCloud endpoint puts around its payload.
+type envelope[T any] struct {
+	Error   bool   `json:"error"`
+	Message string `json:"message"`
+	Data    T      `json:"data"`
+}
 
-	fullURL := endpoint + "?" + params.Encode()
+// request describes a single API call to one service.
+type request struct {
+	method  string
+	service Service
+	// path is relative to the service endpoint.
+	path string
+	// query is sent in addition to the region and project_id parameters.
+	query url.Values
+	body  interface{}
+	// action describes the call in error messages, e.g. "create VM".
+	action string
+}
 
-	req, err := c.newRequest(ctx, "GET", fullURL, nil)
+// do performs r and decodes the envelope's data into a T. Every error is
+// wrapped as "failed to <action>: ...".
+func do[T any](ctx context.Context, c *AceCloudClient, r request) (*T, error) {
+	var env envelope[T]
+	requestID, err := c.send(ctx, r, &env)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, fmt.Errorf("failed to %s: %w", r.action, err)
 	}
 
-	var getResp types.VMGetResponse
-	if err := c.doRequest(req, &getResp); err != nil {
-		return nil, fmt.Errorf("failed to get VM: %w", err)
+	if env.Error {
+		return nil, fmt.Errorf("failed to %s: %w", r.action, &APIError{Message: env.Message, RequestID: requestID})
 	}
 
-	if getResp.Error {
-		return nil, fmt.Errorf("API returned error: %s", getResp.Message)
-	}
-
-	return &getResp, nil
+	return &env.Data, nil
 }
 
-// DeleteVMs deletes one or more VMs by IDs using the bulk-delete endpoint.
-// The API expects a JSON body like: {"key":"id","values":["id1","id2"]}
-func (c *AceCloudClient) DeleteVMs(ctx context.Context, ids []string) (*types.DeleteResponse, error) {
-	endpoint := fmt.Sprintf("%s/cloud/instances", c.BaseURL)
-	tflog.Debug(ctx, fmt.Sprintf("Deleting VMs with endpoint: %s", endpoint))
-
+// send builds the URL for r, performs the request and decodes the raw
+// response body into v. It returns the ID of the last request sent.
+func (c *AceCloudClient) send(ctx context.Context, r request, v interface{}) (string, error) {
 	params := url.Values{}
 	params.Add("region", c.Region)
 	params.Add("project_id", c.ProjectID)
+	for k, vs := range r.query {
+		for _, val := range vs {
+			params.Add(k, val)
+		}
+	}
 
-	fullURL := endpoint + "?" + params.Encode()
+	endpoint := c.endpoint(r.service) + r.path
+	tflog.Debug(ctx, fmt.Sprintf("Calling AceCloud API to %s", r.action), map[string]interface{}{
+		"method":   r.method,
+		"service":  r.service.Name,
+		"endpoint": endpoint,
+	})
 
-	body := map[string]interface{}{
-		"key":    "id",
-		"values": ids,
-	}
+	for attempt := 0; ; attempt++ {
+		req, err := c.newRequest(ctx, r.method, endpoint+"?"+params.Encode(), r.service.Name, r.body)
+		if err != nil {
+			return "", fmt.Errorf("failed to create request: %w", err)
+		}
+		requestID := req.Header.Get(RequestIDHeader)
 
-	req, err := c.newRequest(ctx, "DELETE", fullURL, body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create delete request: %w", err)
-	}
+		if c.Limiter == nil {
+			return requestID, c.doRequest(req, v)
+		}
 
-	var delResp types.DeleteResponse
-	if err := c.doRequest(req, &delResp); err != nil {
-		return nil, fmt.Errorf("failed to delete VMs: %w", err)
-	}
+		release, err := c.Limiter.wait(ctx, r.service)
+		if err != nil {
+			return "", fmt.Errorf("request failed: %w", err)
+		}
+		err = c.doRequest(req, v)
+		release()
+
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusTooManyRequests {
+			if err == nil {
+				c.Limiter.succeeded(r.service)
+			}
+			return requestID, err
+		}
+		if attempt == maxThrottledRetries {
+			return requestID, err
+		}
 
-	if delResp.Error {
-		return nil, fmt.Errorf("API returned error: %s", delResp.Message)
+		delay := c.Limiter.throttled(r.service, apiErr.retryAfter, attempt)
+		tflog.Warn(ctx, "AceCloud API is throttling requests, retrying", map[string]interface{}{
+			"service":    r.service.Name,
+			"attempt":    attempt + 1,
+			"delay":      delay.String(),
+			"request_id": requestID,
+		})
 	}
-
-	return &delResp, nil
 }
 
-// UpdateVM updates a VM's attributes (currently supports updating the name)
-func (c *AceCloudClient) UpdateVM(ctx context.Context, id string, body interface{}) (*types.VMUpdateResponse, error) {
-	endpoint := fmt.Sprintf("%s/cloud/instances/%s", c.BaseURL, id)
-	tflog.Debug(ctx, fmt.Sprintf("Updating VM with endpoint: %s", endpoint))
+func (c *AceCloudClient) CreateVM(ctx context.Context, vmReq *types.VMCreateRequest) (*types.VM, error) {
+	return do[types.VM](ctx, c, request{
+		method:  "POST",
+		service: ServiceCompute,
+		path:    "/instances",
+		body:    vmReq,
+		action:  "create VM",
+	})
+}
 
-	params := url.Values{}
-	params.Add("region", c.Region)
-	params.Add("project_id", c.ProjectID)
+func (c *AceCloudClient) GetVM(ctx context.Context, id string) (*types.VM, error) {
+	return do[types.VM](ctx, c, request{
+		method:  "GET",
+		service: ServiceCompute,
+		path:    "/instances/" + url.PathEscape(id),
+		action:  "get VM",
+	})
+}
 
-	fullURL := endpoint + "?" + params.Encode()
+// VMs returns an iterator over the instances in the region and project.
+func (c *AceCloudClient) VMs(ctx context.Context, opts *ListOptions) iter.Seq2[types.VM, error] {
+	return paginate[types.VM](ctx, c, ServiceCompute, "/instances", "VMs", opts)
+}
 
-	req, err := c.newRequest(ctx, "PUT", fullURL, body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create update request: %w", err)
-	}
+func (c *AceCloudClient) ListVMs(ctx context.Context, opts *ListOptions) ([]types.VM, error) {
+	return Collect(c.VMs(ctx, opts))
+}
 
-	var updResp types.VMUpdateResponse
-	if err := c.doRequest(req, &updResp); err != nil {
-		return nil, fmt.Errorf("failed to update VM: %w", err)
-	}
+// DeleteVMs deletes one or more VMs by IDs using the bulk-delete endpoint.
+// The API expects a JSON body like: {"key":"id","values":["id1","id2"]}
+func (c *AceCloudClient) DeleteVMs(ctx context.Context, ids []string) error {
+	_, err := do[json.RawMessage](ctx, c, request{
+		method:  "DELETE",
+		service: ServiceCompute,
+		path:    "/instances",
+		body: map[string]interface{}{
+			"key":    "id",
+			"values": ids,
+		},
+		action: "delete VMs",
+	})
+	return err
+}
 
-	if updResp.Error {
-		return nil, fmt.Errorf("API returned error: %s", updResp.Message)
-	}
+// UpdateVM updates a VM's attributes (currently supports updating the name)
+func (c *AceCloudClient) UpdateVM(ctx context.Context, id string, body interface{}) (*types.VM, error) {
+	return do[types.VM](ctx, c, request{
+		method:  "PUT",
+		service: ServiceCompute,
+		path:    "/instances/" + url.PathEscape(id),
+		body:    body,
+		action:  "update VM",
+	})
+}
 
-	return &updResp, nil
+// GetVMPassword returns the administrator password generated for a VM.
+func (c *AceCloudClient) GetVMPassword(ctx context.Context, id string) (*types.VMPassword, error) {
+	return do[types.VMPassword](ctx, c, request{
+		method:  "GET",
+		service: ServiceCompute,
+		path:    "/instances/" + url.PathEscape(id) + "/password",
+		action:  "get VM password",
+	})
 }
 
-func (c *AceCloudClient) newRequest(ctx context.Context, method, url string, body interface{}) (*http.Request, error) {
+func (c *AceCloudClient) newRequest(ctx context.Context, method, url, service string, body interface{}) (*http.Request, error) {
 	var buf io.Reader
 	if body != nil {
 		jsonData, err := json.Marshal(body)
@@ -166,44 +286,85 @@ func (c *AceCloudClient) newRequest(ctx context.Context, method, url string, bod
 		return nil, err
 	}
 
+	requestID, err := uuid.GenerateUUID()
+	if err != nil {
+		return nil, err
+	}
+
 	// Set headers
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(RequestIDHeader, requestID)
+	if c.UserAgent != "" {
+		req.Header.Set("User-Agent", c.UserAgent)
+	}
 	req.Header.Set("x-ace-api-key", c.APIKey)
-	req.Header.Set("x-api-key-service-name", "ace_vm")
-	dump, err := httputil.DumpRequestOut(req, true)
+	req.Header.Set("x-api-key-service-name", service)
+	if c.ClientID != 0 {
+		req.Header.Set("x-ace-client-id", strconv.Itoa(c.ClientID))
+	}
+	if c.UserID != 0 {
+		req.Header.Set("x-ace-user-id", strconv.Itoa(c.UserID))
+	}
+	dump, err := dumpRequest(req)
 	if err != nil {
 		tflog.Debug(ctx, fmt.Sprintf("Failed to dump request: %v", err))
 	} else {
 		tflog.Debug(ctx, "HTTP Request", map[string]interface{}{
-			"request": string(dump),
+			"request":    dump,
+			"request_id": requestID,
 		})
 	}
 	return req, nil
 }
 
+// dumpRequest renders req for the debug log. The API key is redacted and the
+// body left out, since bodies carry passwords and secret keys; req itself is
+// not modified.
+func dumpRequest(req *http.Request) (string, error) {
+	clone := req.Clone(req.Context())
+	if clone.Header.Get("x-ace-api-key") != "" {
+		clone.Header.Set("x-ace-api-key", "REDACTED")
+	}
+	dump, err := httputil.DumpRequestOut(clone, false)
+	return string(dump), err
+}
+
+// doRequest sends req and decodes the response body into v. Every error names
+// the request's X-Request-ID.
 func (c *AceCloudClient) doRequest(req *http.Request, v interface{}) error {
+	requestID := req.Header.Get(RequestIDHeader)
+
 	resp, err := c.HTTPClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("request failed: %w", err)
+		return fmt.Errorf("request failed: %w (request ID: %s)", err, requestID)
 	}
 	defer resp.Body.Close()
 
+	tflog.Debug(req.Context(), "HTTP Response", map[string]interface{}{
+		"status":     resp.StatusCode,
+		"request_id": requestID,
+	})
+
 	// Read the response body
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return fmt.Errorf("failed to read response body: %w", err)
+		return fmt.Errorf("failed to read response body: %w (request ID: %s)", err, requestID)
 	}
 
 	// Check for HTTP error status codes
 	if resp.StatusCode >= 400 {
+		apiErr := &APIError{StatusCode: resp.StatusCode, Message: string(body), RequestID: requestID}
 		var apiError struct {
 			Error   bool   `json:"error"`
 			Message string `json:"message"`
 		}
 		if err := json.Unmarshal(body, &apiError); err == nil && apiError.Message != "" {
-			return fmt.Errorf("API error %d: %s", resp.StatusCode, apiError.Message)
+			apiErr.Message = apiError.Message
+		}
+		if resp.StatusCode == http.StatusTooManyRequests {
+			apiErr.retryAfter = parseRetryAfter(resp.Header)
 		}
-		return fmt.Errorf("API error %d: %s", resp.StatusCode, string(body))
+		return apiErr
 	}
 
 	if v == nil {
@@ -211,7 +372,7 @@ func (c *AceCloudClient) doRequest(req *http.Request, v interface{}) error {
 	}
 
 	if err := json.Unmarshal(body, v); err != nil {
-		return fmt.Errorf("failed to parse response: %w", err)
+		return fmt.Errorf("failed to parse response: %w (request ID: %s)", err, requestID)
 	}
 
 	return nil
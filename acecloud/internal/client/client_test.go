@@ -0,0 +1,470 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/client/types"
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/recorder"
+	"github.com/hashicorp/go-uuid"
+)
+
+// The region and project every cassette is recorded for. Recording replaces
+// the real values with these.
+const (
+	testRegion  = "test-region"
+	testProject = "test-project"
+)
+
+// redactedFields are the secrets the API sends or receives in JSON bodies.
+var redactedFields = []string{
+	"access_key",
+	"admin_password",
+	"email",
+	"kubeconfig",
+	"password",
+	"secret_key",
+	"token",
+}
+
+// recordedClient is a client whose requests are replayed from the test's
+// cassette, testdata/cassettes/<test name>.json. With recorder.EnvVar set,
+// the requests go to ACECLOUD_API_ENDPOINT instead and the cassette is
+// recorded again.
+type recordedClient struct {
+	*AceCloudClient
+	rec *recorder.Recorder
+}
+
+func newRecordedClient(t *testing.T) *recordedClient {
+	t.Helper()
+
+	opts := recorder.Options{
+		Mode:         recorder.ModeFromEnv(),
+		RedactFields: redactedFields,
+	}
+	c := NewAceCloudClient("https://api.acecloud.test", "test-api-key", testRegion, testProject)
+
+	if opts.Mode == recorder.ModeRecord {
+		var missing []string
+		for _, name := range []string{"ACECLOUD_API_ENDPOINT", "ACECLOUD_API_KEY", "ACECLOUD_REGION", "ACECLOUD_PROJECT_ID"} {
+			if os.Getenv(name) == "" {
+				missing = append(missing, name)
+			}
+		}
+		if len(missing) > 0 {
+			t.Fatalf("%s must be set to record cassettes", strings.Join(missing, ", "))
+		}
+
+		c = NewAceCloudClient(os.Getenv("ACECLOUD_API_ENDPOINT"), os.Getenv("ACECLOUD_API_KEY"), os.Getenv("ACECLOUD_REGION"), os.Getenv("ACECLOUD_PROJECT_ID"))
+		opts.Replacements = map[string]string{
+			c.APIKey:    recorder.Redacted,
+			c.Region:    testRegion,
+			c.ProjectID: testProject,
+		}
+	}
+
+	rec, err := recorder.New(filepath.Join("testdata", "cassettes", t.Name()+".json"), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := rec.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	c.HTTPClient = &http.Client{Transport: rec}
+	return &recordedClient{AceCloudClient: c, rec: rec}
+}
+
+// input returns the value of the environment variable env when recording,
+// and placeholder when replaying. The recorded value is written to the
+// cassette as placeholder, so that replays send the same requests.
+func (c *recordedClient) input(t *testing.T, env, placeholder string) string {
+	t.Helper()
+	if c.rec.Mode() != recorder.ModeRecord {
+		return placeholder
+	}
+	v := os.Getenv(env)
+	if v == "" {
+		t.Fatalf("%s must be set to record %s", env, t.Name())
+	}
+	c.rec.Replace(v, placeholder)
+	return v
+}
+
+// waitFor calls done until it reports true. Only recording sleeps between
+// calls; a replay runs through the recorded calls at once.
+func (c *recordedClient) waitFor(t *testing.T, what string, done func() (bool, error)) {
+	t.Helper()
+	interval := time.Duration(0)
+	if c.rec.Mode() == recorder.ModeRecord {
+		interval = 5 * time.Second
+	}
+
+	for deadline := time.Now().Add(30 * time.Minute); time.Now().Before(deadline); time.Sleep(interval) {
+		ok, err := done()
+		if err != nil {
+			t.Fatalf("waiting for %s: %s", what, err)
+		}
+		if ok {
+			return
+		}
+	}
+	t.Fatalf("timed out waiting for %s", what)
+}
+
+func TestVMLifecycle(t *testing.T) {
+	ctx := context.Background()
+	c := newRecordedClient(t)
+
+	vm, err := c.CreateVM(ctx, &types.VMCreateRequest{
+		Name:                "tf-rec-vm",
+		Flavor:              c.input(t, "ACECLOUD_TEST_FLAVOR", "C.2x4"),
+		BootUUID:            c.input(t, "ACECLOUD_TEST_IMAGE", "image-1"),
+		Key:                 c.input(t, "ACECLOUD_TEST_KEY", "tf-rec-key"),
+		SourceType:          "image",
+		BillingType:         "hourly",
+		DeleteOnTermination: true,
+	})
+	if err != nil {
+		t.Fatalf("CreateVM: %s", err)
+	}
+	if vm.ID == "" || vm.Name != "tf-rec-vm" {
+		t.Fatalf("CreateVM returned %+v", vm)
+	}
+
+	c.waitFor(t, "VM to become active", func() (bool, error) {
+		got, err := c.GetVM(ctx, vm.ID)
+		if err != nil {
+			return false, err
+		}
+		vm = got
+		return got.Status == "ACTIVE", nil
+	})
+	if vm.PublicIP() == "" {
+		t.Error("active VM has no public address")
+	}
+
+	password, err := c.GetVMPassword(ctx, vm.ID)
+	if err != nil {
+		t.Fatalf("GetVMPassword: %s", err)
+	}
+	if password.Password == "" {
+		t.Error("GetVMPassword returned an empty password")
+	}
+
+	updated, err := c.UpdateVM(ctx, vm.ID, &types.VMUpdateRequest{Name: "tf-rec-vm-renamed"})
+	if err != nil {
+		t.Fatalf("UpdateVM: %s", err)
+	}
+	if updated.Name != "tf-rec-vm-renamed" {
+		t.Errorf("UpdateVM returned name %q", updated.Name)
+	}
+
+	vms, err := c.ListVMs(ctx, &ListOptions{Filters: map[string][]string{"name": {"tf-rec-vm-renamed"}}})
+	if err != nil {
+		t.Fatalf("ListVMs: %s", err)
+	}
+	if len(vms) != 1 || vms[0].ID != vm.ID {
+		t.Errorf("ListVMs returned %+v, want only %s", vms, vm.ID)
+	}
+
+	if err := c.DeleteVMs(ctx, []string{vm.ID}); err != nil {
+		t.Fatalf("DeleteVMs: %s", err)
+	}
+	c.waitFor(t, "VM to be deleted", func() (bool, error) {
+		_, err := c.GetVM(ctx, vm.ID)
+		if IsNotFound(err) {
+			return true, nil
+		}
+		return false, err
+	})
+
+	if err := c.DeleteVMs(ctx, []string{vm.ID}); !IsNotFound(err) {
+		t.Errorf("DeleteVMs of a deleted VM returned %v, want a 404", err)
+	}
+}
+
+func TestGetVMNotFound(t *testing.T) {
+	c := newRecordedClient(t)
+
+	_, err := c.GetVM(context.Background(), "tf-rec-missing")
+	if !IsNotFound(err) {
+		t.Fatalf("GetVM of a missing VM returned %v, want a 404", err)
+	}
+	if !strings.HasPrefix(err.Error(), "failed to get VM: API error 404: ") {
+		t.Errorf("error = %q", err)
+	}
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+type errReader struct{}
+
+func (errReader) Read([]byte) (int, error) {
+	return 0, errors.New("connection reset")
+}
+
+func stubClient(rt roundTripFunc) *AceCloudClient {
+	c := NewAceCloudClient("https://api.acecloud.test", "test-api-key", testRegion, testProject)
+	c.HTTPClient = &http.Client{Transport: rt}
+	return c
+}
+
+func respond(status int, body string) roundTripFunc {
+	return func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: status,
+			Header:     http.Header{"Content-Type": {"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    req,
+		}, nil
+	}
+}
+
+func TestDo(t *testing.T) {
+	var got *http.Request
+	var body []byte
+	c := stubClient(func(req *http.Request) (*http.Response, error) {
+		got = req
+		body, _ = io.ReadAll(req.Body)
+		return respond(http.StatusOK, `{"data":{"id":"zone-1","name":"example.com."}}`)(req)
+	})
+
+	zone, err := c.CreateDNSZone(context.Background(), &types.DNSZoneCreateRequest{Name: "example.com", TTL: 300})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if zone.ID != "zone-1" || zone.Name != "example.com." {
+		t.Errorf("decoded %+v", zone)
+	}
+	if got.Method != "POST" || got.URL.String() != "https://api.acecloud.test/dns/zones?project_id=test-project&region=test-region" {
+		t.Errorf("sent %s %s", got.Method, got.URL)
+	}
+	if got.Header.Get("x-ace-api-key") != "test-api-key" || got.Header.Get("x-api-key-service-name") != "ace_dns" {
+		t.Errorf("headers = %v", got.Header)
+	}
+	if string(body) != `{"name":"example.com","ttl":300}` {
+		t.Errorf("body = %s", body)
+	}
+}
+
+func TestDoErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		rt        roundTripFunc
+		wantErr   string
+		wantCode  int
+		notFound  bool
+		authError bool
+	}{
+		{
+			name: "transport error",
+			rt: func(*http.Request) (*http.Response, error) {
+				return nil, errors.New("dial tcp: connection refused")
+			},
+			wantErr: "failed to get DNS zone: request failed",
+		},
+		{
+			name: "unreadable body",
+			rt: func(req *http.Request) (*http.Response, error) {
+				return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(errReader{}), Request: req}, nil
+			},
+			wantErr: "failed to get DNS zone: failed to read response body: connection reset",
+		},
+		{
+			name:    "error envelope",
+			rt:      respond(http.StatusOK, `{"error":true,"message":"quota exceeded"}`),
+			wantErr: "failed to get DNS zone: API returned error: quota exceeded",
+		},
+		{
+			name:     "not found",
+			rt:       respond(http.StatusNotFound, `{"error":true,"message":"zone not found"}`),
+			wantErr:  "failed to get DNS zone: API error 404: zone not found",
+			wantCode: http.StatusNotFound,
+			notFound: true,
+		},
+		{
+			name:     "plain text error",
+			rt:       respond(http.StatusBadGateway, "upstream unavailable"),
+			wantErr:  "failed to get DNS zone: API error 502: upstream unavailable",
+			wantCode: http.StatusBadGateway,
+		},
+		{
+			name:      "unauthorized",
+			rt:        respond(http.StatusUnauthorized, `{"error":true,"message":"invalid API key"}`),
+			wantErr:   "failed to get DNS zone: API error 401: invalid API key",
+			wantCode:  http.StatusUnauthorized,
+			authError: true,
+		},
+		{
+			name:    "invalid JSON",
+			rt:      respond(http.StatusOK, `{"data":`),
+			wantErr: "failed to get DNS zone: failed to parse response",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requestID string
+			_, err := stubClient(func(req *http.Request) (*http.Response, error) {
+				requestID = req.Header.Get(RequestIDHeader)
+				return tt.rt(req)
+			}).GetDNSZone(context.Background(), "zone-1")
+			if err == nil || !strings.HasPrefix(err.Error(), tt.wantErr) {
+				t.Fatalf("error = %v, want %q", err, tt.wantErr)
+			}
+			if suffix := " (request ID: " + requestID + ")"; !strings.HasSuffix(err.Error(), suffix) {
+				t.Errorf("error = %q, want the suffix %q", err, suffix)
+			}
+			var apiErr *APIError
+			if errors.As(err, &apiErr) && (apiErr.StatusCode != tt.wantCode || apiErr.RequestID != requestID) {
+				t.Errorf("status = %d, request ID = %q, want %d, %q", apiErr.StatusCode, apiErr.RequestID, tt.wantCode, requestID)
+			}
+			if IsNotFound(err) != tt.notFound {
+				t.Errorf("IsNotFound = %t, want %t", IsNotFound(err), tt.notFound)
+			}
+			if IsAuthError(err) != tt.authError {
+				t.Errorf("IsAuthError = %t, want %t", IsAuthError(err), tt.authError)
+			}
+		})
+	}
+}
+
+// Every request gets its own ID, and the User-Agent once it is set.
+func TestRequestIDAndUserAgent(t *testing.T) {
+	var headers []http.Header
+	c := stubClient(func(req *http.Request) (*http.Response, error) {
+		headers = append(headers, req.Header)
+		return respond(http.StatusOK, `{"data":{}}`)(req)
+	})
+	c.UserAgent = "terraform-provider-acecloud/test"
+
+	for range 2 {
+		if _, err := c.GetVM(context.Background(), "vm-1"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	first := headers[0].Get(RequestIDHeader)
+	if _, err := uuid.ParseUUID(first); err != nil {
+		t.Errorf("%s = %q, want a UUID", RequestIDHeader, first)
+	}
+	if id := headers[1].Get(RequestIDHeader); id == first {
+		t.Errorf("two requests were sent with the ID %s", id)
+	}
+	if ua := headers[0].Get("User-Agent"); ua != "terraform-provider-acecloud/test" {
+		t.Errorf("User-Agent = %q", ua)
+	}
+}
+
+func TestDoRequestWithoutResult(t *testing.T) {
+	c := stubClient(respond(http.StatusOK, "not JSON"))
+	req, err := http.NewRequest("DELETE", "https://api.acecloud.test/cloud/instances", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.doRequest(req, nil); err != nil {
+		t.Errorf("doRequest without a result parsed the body: %s", err)
+	}
+}
+
+// An endpoint override replaces the base URL and the service's base path of
+// that service only.
+func TestEndpointOverride(t *testing.T) {
+	var urls []string
+	c := stubClient(func(req *http.Request) (*http.Response, error) {
+		urls = append(urls, req.Header.Get("x-api-key-service-name")+" "+req.URL.Scheme+"://"+req.URL.Host+req.URL.Path)
+		return respond(http.StatusOK, `{"data":{}}`)(req)
+	})
+	c.Endpoints = map[string]string{ServiceDNS.Key: "https://dns.internal.test/v2/"}
+
+	if _, err := c.GetDNSZone(context.Background(), "zone-1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetDatabaseInstance(context.Background(), "db-1"); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"ace_dns https://dns.internal.test/v2/zones/zone-1",
+		"ace_dbaas https://api.acecloud.test/dbaas/instances/db-1",
+	}
+	if !slices.Equal(urls, want) {
+		t.Errorf("requests = %q, want %q", urls, want)
+	}
+}
+
+func TestWithScope(t *testing.T) {
+	c := NewAceCloudClient("https://api.acecloud.test", "test-api-key", "test-region", "test-project")
+
+	if c.WithScope("", "") != c || c.WithScope("test-region", "test-project") != c {
+		t.Error("WithScope copied the client for its own scope")
+	}
+	scoped := c.WithScope("other-region", "")
+	if scoped == c || scoped.Region != "other-region" || scoped.ProjectID != "test-project" || scoped.HTTPClient != c.HTTPClient {
+		t.Errorf("WithScope(other-region) = %+v", scoped)
+	}
+	if c.Region != "test-region" {
+		t.Errorf("WithScope changed the original client's region to %q", c.Region)
+	}
+}
+
+// client_id and user_id are only sent once configured.
+func TestIdentityHeaders(t *testing.T) {
+	var headers []http.Header
+	c := stubClient(func(req *http.Request) (*http.Response, error) {
+		headers = append(headers, req.Header)
+		return respond(http.StatusOK, `{"data":{"account_id":"acc-1"}}`)(req)
+	})
+
+	if _, err := c.GetCallerIdentity(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	c.ClientID, c.UserID = 42, 7
+	if _, err := c.GetCallerIdentity(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := headers[0]["X-Ace-Client-Id"]; ok {
+		t.Errorf("unconfigured client_id sent as %q", headers[0].Get("x-ace-client-id"))
+	}
+	if headers[1].Get("x-ace-client-id") != "42" || headers[1].Get("x-ace-user-id") != "7" {
+		t.Errorf("headers = %v, want client 42 and user 7", headers[1])
+	}
+}
+
+// The debug dump of a request must not leak the API key or the body, nor
+// consume the body that is about to be sent.
+func TestDumpRequest(t *testing.T) {
+	c := NewAceCloudClient("https://api.acecloud.test", "test-api-key", "test-region", "test-project")
+	req, err := c.newRequest(context.Background(), "POST", "https://api.acecloud.test/dbaas/instances", ServiceDatabase.Name, map[string]string{"admin_password": "s3cret-password"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dump, err := dumpRequest(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(dump, "test-api-key") || strings.Contains(dump, "s3cret-password") || !strings.Contains(dump, "X-Ace-Api-Key: REDACTED") {
+		t.Errorf("dump = %s", dump)
+	}
+	body, _ := io.ReadAll(req.Body)
+	if req.Header.Get("x-ace-api-key") != "test-api-key" || string(body) != `{"admin_password":"s3cret-password"}` {
+		t.Errorf("request after the dump has API key %q and body %s", req.Header.Get("x-ace-api-key"), body)
+	}
+}
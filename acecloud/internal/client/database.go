@@ -0,0 +1,119 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/client/types"
+)
+
+// CreateDatabaseInstance starts provisioning a managed database instance. The
+// returned instance is usually still building; poll GetDatabaseInstance until
+// it becomes active.
+func (c *AceCloudClient) CreateDatabaseInstance(ctx context.Context, dbReq *types.DatabaseInstanceCreateRequest) (*types.DatabaseInstance, error) {
+	return do[types.DatabaseInstance](ctx, c, request{
+		method:  "POST",
+		service: ServiceDatabase,
+		path:    "/instances",
+		body:    dbReq,
+		action:  "create database instance",
+	})
+}
+
+func (c *AceCloudClient) GetDatabaseInstance(ctx context.Context, id string) (*types.DatabaseInstance, error) {
+	return do[types.DatabaseInstance](ctx, c, request{
+		method:  "GET",
+		service: ServiceDatabase,
+		path:    "/instances/" + url.PathEscape(id),
+		action:  "get database instance",
+	})
+}
+
+func (c *AceCloudClient) UpdateDatabaseInstance(ctx context.Context, id string, dbReq *types.DatabaseInstanceUpdateRequest) (*types.DatabaseInstance, error) {
+	return do[types.DatabaseInstance](ctx, c, request{
+		method:  "PUT",
+		service: ServiceDatabase,
+		path:    "/instances/" + url.PathEscape(id),
+		body:    dbReq,
+		action:  "update database instance",
+	})
+}
+
+func (c *AceCloudClient) DeleteDatabaseInstance(ctx context.Context, id string) error {
+	_, err := do[json.RawMessage](ctx, c, request{
+		method:  "DELETE",
+		service: ServiceDatabase,
+		path:    "/instances/" + url.PathEscape(id),
+		action:  "delete database instance",
+	})
+	return err
+}
+
+func (c *AceCloudClient) CreateDatabaseUser(ctx context.Context, instanceID string, userReq *types.DatabaseUserCreateRequest) (*types.DatabaseUser, error) {
+	return do[types.DatabaseUser](ctx, c, request{
+		method:  "POST",
+		service: ServiceDatabase,
+		path:    "/instances/" + url.PathEscape(instanceID) + "/users",
+		body:    userReq,
+		action:  "create database user",
+	})
+}
+
+func (c *AceCloudClient) GetDatabaseUser(ctx context.Context, instanceID, name string) (*types.DatabaseUser, error) {
+	return do[types.DatabaseUser](ctx, c, request{
+		method:  "GET",
+		service: ServiceDatabase,
+		path:    "/instances/" + url.PathEscape(instanceID) + "/users/" + url.PathEscape(name),
+		action:  "get database user",
+	})
+}
+
+func (c *AceCloudClient) UpdateDatabaseUser(ctx context.Context, instanceID, name string, userReq *types.DatabaseUserUpdateRequest) (*types.DatabaseUser, error) {
+	return do[types.DatabaseUser](ctx, c, request{
+		method:  "PUT",
+		service: ServiceDatabase,
+		path:    "/instances/" + url.PathEscape(instanceID) + "/users/" + url.PathEscape(name),
+		body:    userReq,
+		action:  "update database user",
+	})
+}
+
+func (c *AceCloudClient) DeleteDatabaseUser(ctx context.Context, instanceID, name string) error {
+	_, err := do[json.RawMessage](ctx, c, request{
+		method:  "DELETE",
+		service: ServiceDatabase,
+		path:    "/instances/" + url.PathEscape(instanceID) + "/users/" + url.PathEscape(name),
+		action:  "delete database user",
+	})
+	return err
+}
+
+func (c *AceCloudClient) CreateDatabase(ctx context.Context, instanceID string, dbReq *types.DatabaseCreateRequest) (*types.Database, error) {
+	return do[types.Database](ctx, c, request{
+		method:  "POST",
+		service: ServiceDatabase,
+		path:    "/instances/" + url.PathEscape(instanceID) + "/databases",
+		body:    dbReq,
+		action:  "create database",
+	})
+}
+
+func (c *AceCloudClient) GetDatabase(ctx context.Context, instanceID, name string) (*types.Database, error) {
+	return do[types.Database](ctx, c, request{
+		method:  "GET",
+		service: ServiceDatabase,
+		path:    "/instances/" + url.PathEscape(instanceID) + "/databases/" + url.PathEscape(name),
+		action:  "get database",
+	})
+}
+
+func (c *AceCloudClient) DeleteDatabase(ctx context.Context, instanceID, name string) error {
+	_, err := do[json.RawMessage](ctx, c, request{
+		method:  "DELETE",
+		service: ServiceDatabase,
+		path:    "/instances/" + url.PathEscape(instanceID) + "/databases/" + url.PathEscape(name),
+		action:  "delete database",
+	})
+	return err
+}
@@ -0,0 +1,106 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/client/types"
+)
+
+func TestDatabaseLifecycle(t *testing.T) {
+	ctx := context.Background()
+	c := newRecordedClient(t)
+
+	inst, err := c.CreateDatabaseInstance(ctx, &types.DatabaseInstanceCreateRequest{
+		Name:                "tf-rec-db",
+		Engine:              "postgresql",
+		Version:             "15",
+		Flavor:              c.input(t, "ACECLOUD_TEST_DB_FLAVOR", "db.small"),
+		StorageSize:         20,
+		BackupWindow:        "02:00-03:00",
+		BackupRetentionDays: 7,
+		AdminUsername:       "tfadmin",
+	})
+	if err != nil {
+		t.Fatalf("CreateDatabaseInstance: %s", err)
+	}
+	if inst.ID == "" || inst.AdminPassword == "" {
+		t.Fatalf("CreateDatabaseInstance returned %+v, want an ID and a generated password", inst)
+	}
+
+	c.waitFor(t, "database instance to become active", func() (bool, error) {
+		got, err := c.GetDatabaseInstance(ctx, inst.ID)
+		if err != nil {
+			return false, err
+		}
+		inst = got
+		return got.Status == "ACTIVE", nil
+	})
+	if inst.Host == "" || inst.Port == 0 {
+		t.Errorf("active instance has no endpoint: %+v", inst)
+	}
+
+	updated, err := c.UpdateDatabaseInstance(ctx, inst.ID, &types.DatabaseInstanceUpdateRequest{
+		Version:             inst.Version,
+		Flavor:              inst.Flavor,
+		StorageSize:         30,
+		BackupWindow:        inst.BackupWindow,
+		BackupRetentionDays: 14,
+		AllowedNetworks:     []string{"10.0.0.0/8"},
+	})
+	if err != nil {
+		t.Fatalf("UpdateDatabaseInstance: %s", err)
+	}
+	if updated.StorageSize != 30 || updated.BackupRetentionDays != 14 {
+		t.Errorf("UpdateDatabaseInstance returned %+v", updated)
+	}
+
+	user, err := c.CreateDatabaseUser(ctx, inst.ID, &types.DatabaseUserCreateRequest{Name: "app", Password: "tf-rec-password-1"})
+	if err != nil {
+		t.Fatalf("CreateDatabaseUser: %s", err)
+	}
+	if user.Name != "app" {
+		t.Errorf("CreateDatabaseUser returned %+v", user)
+	}
+	if _, err := c.GetDatabaseUser(ctx, inst.ID, "app"); err != nil {
+		t.Errorf("GetDatabaseUser: %s", err)
+	}
+	if _, err := c.UpdateDatabaseUser(ctx, inst.ID, "app", &types.DatabaseUserUpdateRequest{Password: "tf-rec-password-2"}); err != nil {
+		t.Errorf("UpdateDatabaseUser: %s", err)
+	}
+
+	db, err := c.CreateDatabase(ctx, inst.ID, &types.DatabaseCreateRequest{Name: "app", Charset: "UTF8"})
+	if err != nil {
+		t.Fatalf("CreateDatabase: %s", err)
+	}
+	if db.Name != "app" || db.Charset != "UTF8" || db.Collation == "" {
+		t.Errorf("CreateDatabase returned %+v", db)
+	}
+	if _, err := c.GetDatabase(ctx, inst.ID, "app"); err != nil {
+		t.Errorf("GetDatabase: %s", err)
+	}
+
+	if err := c.DeleteDatabase(ctx, inst.ID, "app"); err != nil {
+		t.Errorf("DeleteDatabase: %s", err)
+	}
+	if _, err := c.GetDatabase(ctx, inst.ID, "app"); !IsNotFound(err) {
+		t.Errorf("GetDatabase after delete returned %v, want a 404", err)
+	}
+	if err := c.DeleteDatabaseUser(ctx, inst.ID, "app"); err != nil {
+		t.Errorf("DeleteDatabaseUser: %s", err)
+	}
+	if _, err := c.GetDatabaseUser(ctx, inst.ID, "app"); !IsNotFound(err) {
+		t.Errorf("GetDatabaseUser after delete returned %v, want a 404", err)
+	}
+
+	if err := c.DeleteDatabaseInstance(ctx, inst.ID); err != nil {
+		t.Fatalf("DeleteDatabaseInstance: %s", err)
+	}
+	c.waitFor(t, "database instance to be deleted", func() (bool, error) {
+		_, err := c.GetDatabaseInstance(ctx, inst.ID)
+		if IsNotFound(err) {
+			return true, nil
+		}
+		return false, err
+	})
+}
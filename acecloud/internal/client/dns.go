@@ -0,0 +1,95 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/client/types"
+)
+
+func (c *AceCloudClient) CreateDNSZone(ctx context.Context, zoneReq *types.DNSZoneCreateRequest) (*types.DNSZone, error) {
+	return do[types.DNSZone](ctx, c, request{
+		method:  "POST",
+		service: ServiceDNS,
+		path:    "/zones",
+		body:    zoneReq,
+		action:  "create DNS zone",
+	})
+}
+
+func (c *AceCloudClient) GetDNSZone(ctx context.Context, id string) (*types.DNSZone, error) {
+	return do[types.DNSZone](ctx, c, request{
+		method:  "GET",
+		service: ServiceDNS,
+		path:    "/zones/" + url.PathEscape(id),
+		action:  "get DNS zone",
+	})
+}
+
+func (c *AceCloudClient) UpdateDNSZone(ctx context.Context, id string, zoneReq *types.DNSZoneUpdateRequest) (*types.DNSZone, error) {
+	return do[types.DNSZone](ctx, c, request{
+		method:  "PUT",
+		service: ServiceDNS,
+		path:    "/zones/" + url.PathEscape(id),
+		body:    zoneReq,
+		action:  "update DNS zone",
+	})
+}
+
+func (c *AceCloudClient) DeleteDNSZone(ctx context.Context, id string) error {
+	_, err := do[json.RawMessage](ctx, c, request{
+		method:  "DELETE",
+		service: ServiceDNS,
+		path:    "/zones/" + url.PathEscape(id),
+		action:  "delete DNS zone",
+	})
+	return err
+}
+
+// CreateDNSRecordSet creates all records of one name and type in a zone. The
+// API rejects the call if a record set with the same name and type exists.
+func (c *AceCloudClient) CreateDNSRecordSet(ctx context.Context, zoneID string, rsReq *types.DNSRecordSetCreateRequest) (*types.DNSRecordSet, error) {
+	return do[types.DNSRecordSet](ctx, c, request{
+		method:  "POST",
+		service: ServiceDNS,
+		path:    "/zones/" + url.PathEscape(zoneID) + "/recordsets",
+		body:    rsReq,
+		action:  "create DNS record set",
+	})
+}
+
+func (c *AceCloudClient) GetDNSRecordSet(ctx context.Context, zoneID, name, recordType string) (*types.DNSRecordSet, error) {
+	return do[types.DNSRecordSet](ctx, c, request{
+		method:  "GET",
+		service: ServiceDNS,
+		path:    recordSetPath(zoneID, name, recordType),
+		action:  "get DNS record set",
+	})
+}
+
+// UpdateDNSRecordSet replaces the TTL and the full list of records of a
+// record set.
+func (c *AceCloudClient) UpdateDNSRecordSet(ctx context.Context, zoneID, name, recordType string, rsReq *types.DNSRecordSetUpdateRequest) (*types.DNSRecordSet, error) {
+	return do[types.DNSRecordSet](ctx, c, request{
+		method:  "PUT",
+		service: ServiceDNS,
+		path:    recordSetPath(zoneID, name, recordType),
+		body:    rsReq,
+		action:  "update DNS record set",
+	})
+}
+
+func (c *AceCloudClient) DeleteDNSRecordSet(ctx context.Context, zoneID, name, recordType string) error {
+	_, err := do[json.RawMessage](ctx, c, request{
+		method:  "DELETE",
+		service: ServiceDNS,
+		path:    recordSetPath(zoneID, name, recordType),
+		action:  "delete DNS record set",
+	})
+	return err
+}
+
+func recordSetPath(zoneID, name, recordType string) string {
+	return "/zones/" + url.PathEscape(zoneID) + "/recordsets/" + url.PathEscape(name) + "/" + url.PathEscape(recordType)
+}
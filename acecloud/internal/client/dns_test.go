@@ -0,0 +1,84 @@
+package client
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/client/types"
+)
+
+func TestDNSLifecycle(t *testing.T) {
+	ctx := context.Background()
+	c := newRecordedClient(t)
+	domain := c.input(t, "ACECLOUD_TEST_DNS_DOMAIN", "tf-rec.example.com.")
+
+	zone, err := c.CreateDNSZone(ctx, &types.DNSZoneCreateRequest{
+		Name:        domain,
+		Email:       "hostmaster@example.com",
+		Description: "recorded by the client tests",
+		TTL:         3600,
+	})
+	if err != nil {
+		t.Fatalf("CreateDNSZone: %s", err)
+	}
+	if zone.ID == "" || zone.Name != domain || len(zone.NameServers) == 0 {
+		t.Fatalf("CreateDNSZone returned %+v", zone)
+	}
+
+	if _, err := c.GetDNSZone(ctx, zone.ID); err != nil {
+		t.Errorf("GetDNSZone: %s", err)
+	}
+	updated, err := c.UpdateDNSZone(ctx, zone.ID, &types.DNSZoneUpdateRequest{
+		Email:       "hostmaster@example.com",
+		Description: "updated",
+		TTL:         600,
+	})
+	if err != nil {
+		t.Fatalf("UpdateDNSZone: %s", err)
+	}
+	if updated.TTL != 600 || updated.Description != "updated" || updated.Serial <= zone.Serial {
+		t.Errorf("UpdateDNSZone returned %+v", updated)
+	}
+
+	rs, err := c.CreateDNSRecordSet(ctx, zone.ID, &types.DNSRecordSetCreateRequest{
+		Name:    "www",
+		Type:    "A",
+		TTL:     300,
+		Records: []string{"203.0.113.10"},
+	})
+	if err != nil {
+		t.Fatalf("CreateDNSRecordSet: %s", err)
+	}
+	if rs.FQDN != "www."+domain {
+		t.Errorf("fqdn = %q, want %q", rs.FQDN, "www."+domain)
+	}
+
+	if _, err := c.GetDNSRecordSet(ctx, zone.ID, "www", "A"); err != nil {
+		t.Errorf("GetDNSRecordSet: %s", err)
+	}
+	rs, err = c.UpdateDNSRecordSet(ctx, zone.ID, "www", "A", &types.DNSRecordSetUpdateRequest{
+		TTL:     60,
+		Records: []string{"203.0.113.10", "203.0.113.11"},
+	})
+	if err != nil {
+		t.Fatalf("UpdateDNSRecordSet: %s", err)
+	}
+	if rs.TTL != 60 || !slices.Equal(rs.Records, []string{"203.0.113.10", "203.0.113.11"}) {
+		t.Errorf("UpdateDNSRecordSet returned %+v", rs)
+	}
+
+	if err := c.DeleteDNSRecordSet(ctx, zone.ID, "www", "A"); err != nil {
+		t.Errorf("DeleteDNSRecordSet: %s", err)
+	}
+	if _, err := c.GetDNSRecordSet(ctx, zone.ID, "www", "A"); !IsNotFound(err) {
+		t.Errorf("GetDNSRecordSet after delete returned %v, want a 404", err)
+	}
+
+	if err := c.DeleteDNSZone(ctx, zone.ID); err != nil {
+		t.Fatalf("DeleteDNSZone: %s", err)
+	}
+	if _, err := c.GetDNSZone(ctx, zone.ID); !IsNotFound(err) {
+		t.Errorf("GetDNSZone after delete returned %v, want a 404", err)
+	}
+}
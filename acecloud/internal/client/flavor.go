@@ -0,0 +1,17 @@
+package client
+
+import (
+	"context"
+	"iter"
+
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/client/types"
+)
+
+// Flavors returns an iterator over the flavors available in the region.
+func (c *AceCloudClient) Flavors(ctx context.Context, opts *ListOptions) iter.Seq2[types.Flavor, error] {
+	return paginate[types.Flavor](ctx, c, ServiceCompute, "/flavors", "flavors", opts)
+}
+
+func (c *AceCloudClient) ListFlavors(ctx context.Context, opts *ListOptions) ([]types.Flavor, error) {
+	return Collect(c.Flavors(ctx, opts))
+}
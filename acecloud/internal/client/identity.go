@@ -0,0 +1,18 @@
+package client
+
+import (
+	"context"
+
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/client/types"
+)
+
+// GetCallerIdentity returns the account, tenant, user and project the API key
+// resolves to.
+func (c *AceCloudClient) GetCallerIdentity(ctx context.Context) (*types.CallerIdentity, error) {
+	return do[types.CallerIdentity](ctx, c, request{
+		method:  "GET",
+		service: ServiceIdentity,
+		path:    "/caller-identity",
+		action:  "get caller identity",
+	})
+}
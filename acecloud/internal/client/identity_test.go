@@ -0,0 +1,21 @@
+package client
+
+import (
+	"context"
+	"testing"
+)
+
+func TestGetCallerIdentity(t *testing.T) {
+	c := newRecordedClient(t)
+
+	id, err := c.GetCallerIdentity(context.Background())
+	if err != nil {
+		t.Fatalf("GetCallerIdentity: %s", err)
+	}
+	if id.AccountID == "" || id.ClientID == 0 || id.UserID == 0 {
+		t.Errorf("GetCallerIdentity returned %+v", id)
+	}
+	if id.ProjectID != c.ProjectID {
+		t.Errorf("project_id = %q, want %q", id.ProjectID, c.ProjectID)
+	}
+}
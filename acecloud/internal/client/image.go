@@ -0,0 +1,17 @@
+package client
+
+import (
+	"context"
+	"iter"
+
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/client/types"
+)
+
+// Images returns an iterator over the images visible to the project.
+func (c *AceCloudClient) Images(ctx context.Context, opts *ListOptions) iter.Seq2[types.Image, error] {
+	return paginate[types.Image](ctx, c, ServiceCompute, "/images", "images", opts)
+}
+
+func (c *AceCloudClient) ListImages(ctx context.Context, opts *ListOptions) ([]types.Image, error) {
+	return Collect(c.Images(ctx, opts))
+}
@@ -0,0 +1,18 @@
+package client
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/client/types"
+)
+
+// GetKubeconfig issues short-lived credentials for a Kubernetes cluster.
+func (c *AceCloudClient) GetKubeconfig(ctx context.Context, clusterID string) (*types.Kubeconfig, error) {
+	return do[types.Kubeconfig](ctx, c, request{
+		method:  "GET",
+		service: ServiceKubernetes,
+		path:    "/clusters/" + url.PathEscape(clusterID) + "/kubeconfig",
+		action:  "get kubeconfig",
+	})
+}
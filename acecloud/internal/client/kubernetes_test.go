@@ -0,0 +1,19 @@
+package client
+
+import (
+	"context"
+	"testing"
+)
+
+func TestGetKubeconfig(t *testing.T) {
+	c := newRecordedClient(t)
+	clusterID := c.input(t, "ACECLOUD_TEST_CLUSTER_ID", "cluster-1")
+
+	kc, err := c.GetKubeconfig(context.Background(), clusterID)
+	if err != nil {
+		t.Fatalf("GetKubeconfig: %s", err)
+	}
+	if kc.Raw == "" || kc.Host == "" || kc.Token == "" || kc.ExpiresAt == "" {
+		t.Errorf("GetKubeconfig returned %+v", kc)
+	}
+}
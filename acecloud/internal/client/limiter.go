@@ -0,0 +1,184 @@
+package client
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultRequestsPerSecond is the default rate of requests to each
+	// service.
+	DefaultRequestsPerSecond = 10
+	// DefaultMaxConcurrentRequests is the default number of requests in
+	// flight at once.
+	DefaultMaxConcurrentRequests = 8
+
+	// maxThrottledRetries is how often a request answered with 429 is sent
+	// again before the error is returned.
+	maxThrottledRetries = 5
+	// minRateFraction bounds how far repeated 429s slow a service down,
+	// relative to the configured rate.
+	minRateFraction = 1.0 / 16
+	// recoveryFraction of the configured rate is given back to a throttled
+	// service with every successful request.
+	recoveryFraction = 1.0 / 20
+)
+
+// Limiter throttles the requests of a client and of the copies WithScope
+// makes of it. Each service has its own token bucket, and a semaphore caps
+// the number of requests in flight across all services.
+//
+// When a service answers 429 the request is retried after the Retry-After
+// delay, or an exponential backoff without one, and the service's rate is
+// halved. The rate recovers gradually as requests succeed again.
+type Limiter struct {
+	rate     float64
+	burst    float64
+	inFlight chan struct{}
+
+	// minBackoff is the first delay after a 429 without Retry-After.
+	minBackoff time.Duration
+
+	mu      sync.Mutex
+	buckets map[string]*bucket
+}
+
+type bucket struct {
+	// rate is the current rate of the service, which 429s lower.
+	rate   float64
+	tokens float64
+	last   time.Time
+	// pausedUntil holds every request to the service after a 429.
+	pausedUntil time.Time
+}
+
+// NewLimiter returns a Limiter allowing requestsPerSecond requests to each
+// service, with bursts of up to one second's worth, and at most
+// maxConcurrent requests in flight. Zero disables either limit.
+func NewLimiter(requestsPerSecond float64, maxConcurrent int) *Limiter {
+	l := &Limiter{
+		rate:       requestsPerSecond,
+		burst:      math.Max(1, math.Ceil(requestsPerSecond)),
+		minBackoff: time.Second,
+		buckets:    map[string]*bucket{},
+	}
+	if maxConcurrent > 0 {
+		l.inFlight = make(chan struct{}, maxConcurrent)
+	}
+	return l
+}
+
+// wait blocks until a request to svc may be sent. The returned function must
+// be called once the response has been read.
+func (l *Limiter) wait(ctx context.Context, svc Service) (func(), error) {
+	if err := l.take(ctx, svc); err != nil {
+		return nil, err
+	}
+
+	if l.inFlight == nil {
+		return func() {}, nil
+	}
+	select {
+	case l.inFlight <- struct{}{}:
+		return func() { <-l.inFlight }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// take reserves a token from svc's bucket and sleeps until it is due.
+func (l *Limiter) take(ctx context.Context, svc Service) error {
+	l.mu.Lock()
+	b := l.bucket(svc)
+	now := time.Now()
+	delay := time.Duration(0)
+	if l.rate > 0 {
+		b.tokens = math.Min(l.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+		b.last = now
+		b.tokens--
+		if b.tokens < 0 {
+			delay = time.Duration(-b.tokens / b.rate * float64(time.Second))
+		}
+	}
+	if pause := b.pausedUntil.Sub(now); pause > delay {
+		delay = pause
+	}
+	l.mu.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		l.mu.Lock()
+		b.tokens++
+		l.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// throttled records a 429 from svc and returns how long the service is
+// paused for. attempt counts the retries of the request so far.
+func (l *Limiter) throttled(svc Service, retryAfter time.Duration, attempt int) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b := l.bucket(svc)
+	if l.rate > 0 {
+		b.rate = math.Max(b.rate/2, l.rate*minRateFraction)
+		b.tokens = math.Min(b.tokens, 0)
+	}
+
+	delay := retryAfter
+	if delay <= 0 {
+		delay = l.minBackoff << attempt
+	}
+	if until := time.Now().Add(delay); until.After(b.pausedUntil) {
+		b.pausedUntil = until
+	}
+	return delay
+}
+
+// succeeded lets svc's rate recover from earlier 429s.
+func (l *Limiter) succeeded(svc Service) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if b := l.bucket(svc); b.rate < l.rate {
+		b.rate = math.Min(l.rate, b.rate+l.rate*recoveryFraction)
+	}
+}
+
+// bucket returns svc's bucket, creating it full. l.mu must be held.
+func (l *Limiter) bucket(svc Service) *bucket {
+	b, ok := l.buckets[svc.Key]
+	if !ok {
+		b = &bucket{rate: l.rate, tokens: l.burst, last: time.Now()}
+		l.buckets[svc.Key] = b
+	}
+	return b
+}
+
+// parseRetryAfter reads a Retry-After header given in seconds or as an HTTP
+// date. It returns 0 when the header is absent or invalid.
+func parseRetryAfter(h http.Header) time.Duration {
+	v := h.Get("Retry-After")
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(v); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return time.Until(t)
+	}
+	return 0
+}
@@ -0,0 +1,167 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLimiterRate(t *testing.T) {
+	c := stubClient(respond(http.StatusOK, `{"data":{}}`))
+	c.Limiter = NewLimiter(20, 0)
+	ctx := context.Background()
+
+	// The first second's worth of requests is a burst; the five after it
+	// wait for the bucket to refill.
+	start := time.Now()
+	for range 25 {
+		if _, err := c.GetVM(ctx, "vm-1"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 200*time.Millisecond || elapsed > 2*time.Second {
+		t.Errorf("25 requests at 20/s took %s, want about 250ms", elapsed)
+	}
+
+	// Other services have buckets of their own.
+	start = time.Now()
+	for range 20 {
+		if _, err := c.GetDNSZone(ctx, "zone-1"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > 100*time.Millisecond {
+		t.Errorf("a burst to another service took %s", elapsed)
+	}
+}
+
+func TestLimiterConcurrency(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	c := stubClient(func(req *http.Request) (*http.Response, error) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		return respond(http.StatusOK, `{"data":{}}`)(req)
+	})
+	c.Limiter = NewLimiter(0, 2)
+
+	// Copies made by WithScope count against the same limit.
+	var wg sync.WaitGroup
+	for i := range 8 {
+		scoped := c
+		if i%2 == 0 {
+			scoped = c.WithScope("other-region", "")
+		}
+		wg.Go(func() {
+			if _, err := scoped.GetVM(context.Background(), "vm-1"); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+	wg.Wait()
+
+	if got := peak.Load(); got != 2 {
+		t.Errorf("peak requests in flight = %d, want 2", got)
+	}
+}
+
+func TestLimiterRetriesThrottled(t *testing.T) {
+	var calls atomic.Int32
+	c := stubClient(func(req *http.Request) (*http.Response, error) {
+		if calls.Add(1) <= 2 {
+			return respond(http.StatusTooManyRequests, `{"error":true,"message":"rate limit exceeded"}`)(req)
+		}
+		return respond(http.StatusOK, `{"data":{"id":"vm-1"}}`)(req)
+	})
+	c.Limiter = NewLimiter(16, 0)
+	c.Limiter.minBackoff = 10 * time.Millisecond
+
+	start := time.Now()
+	vm, err := c.GetVM(context.Background(), "vm-1")
+	if err != nil {
+		t.Fatalf("GetVM: %s", err)
+	}
+	if vm.ID != "vm-1" || calls.Load() != 3 {
+		t.Errorf("GetVM returned %+v after %d calls, want vm-1 after 3", vm, calls.Load())
+	}
+	// Backoff doubles: 10ms, then 20ms.
+	if elapsed := time.Since(start); elapsed < 30*time.Millisecond {
+		t.Errorf("retries took %s, want at least 30ms of backoff", elapsed)
+	}
+
+	// Two 429s halved the rate twice; the successful request gave a little
+	// of it back.
+	if got, want := c.Limiter.buckets["compute"].rate, 16.0/4+16.0/20; got != want {
+		t.Errorf("rate after two 429s and a success = %g, want %g", got, want)
+	}
+	for range 100 {
+		c.Limiter.succeeded(ServiceCompute)
+	}
+	if got := c.Limiter.buckets["compute"].rate; got != 16 {
+		t.Errorf("rate after recovering = %g, want 16", got)
+	}
+}
+
+func TestLimiterGivesUp(t *testing.T) {
+	var calls atomic.Int32
+	c := stubClient(func(req *http.Request) (*http.Response, error) {
+		calls.Add(1)
+		return respond(http.StatusTooManyRequests, `{"error":true,"message":"rate limit exceeded"}`)(req)
+	})
+	c.Limiter = NewLimiter(0, 0)
+	c.Limiter.minBackoff = time.Millisecond
+
+	_, err := c.GetVM(context.Background(), "vm-1")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("GetVM returned %v, want a 429", err)
+	}
+	if got := calls.Load(); got != maxThrottledRetries+1 {
+		t.Errorf("sent %d requests, want %d", got, maxThrottledRetries+1)
+	}
+}
+
+func TestLimiterContext(t *testing.T) {
+	c := stubClient(respond(http.StatusTooManyRequests, `{"error":true,"message":"rate limit exceeded"}`))
+	c.Limiter = NewLimiter(0, 0)
+	c.Limiter.minBackoff = time.Hour
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := c.GetVM(ctx, "vm-1")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("GetVM returned %v, want the context's error", err)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	future := time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)
+	for _, tc := range []struct {
+		header   string
+		min, max time.Duration
+	}{
+		{"", 0, 0},
+		{"3", 3 * time.Second, 3 * time.Second},
+		{"-1", 0, 0},
+		{"soon", 0, 0},
+		{future, 58 * time.Second, time.Minute},
+	} {
+		h := http.Header{}
+		if tc.header != "" {
+			h.Set("Retry-After", tc.header)
+		}
+		if got := parseRetryAfter(h); got < tc.min || got > tc.max {
+			t.Errorf("Retry-After %q = %s, want between %s and %s", tc.header, got, tc.min, tc.max)
+		}
+	}
+}
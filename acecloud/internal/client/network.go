@@ -0,0 +1,26 @@
+package client
+
+import (
+	"context"
+	"iter"
+
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/client/types"
+)
+
+// Networks returns an iterator over the networks visible to the project.
+func (c *AceCloudClient) Networks(ctx context.Context, opts *ListOptions) iter.Seq2[types.Network, error] {
+	return paginate[types.Network](ctx, c, ServiceNetwork, "/networks", "networks", opts)
+}
+
+func (c *AceCloudClient) ListNetworks(ctx context.Context, opts *ListOptions) ([]types.Network, error) {
+	return Collect(c.Networks(ctx, opts))
+}
+
+// SecurityGroups returns an iterator over the security groups of the project.
+func (c *AceCloudClient) SecurityGroups(ctx context.Context, opts *ListOptions) iter.Seq2[types.SecurityGroup, error] {
+	return paginate[types.SecurityGroup](ctx, c, ServiceNetwork, "/security-groups", "security groups", opts)
+}
+
+func (c *AceCloudClient) ListSecurityGroups(ctx context.Context, opts *ListOptions) ([]types.SecurityGroup, error) {
+	return Collect(c.SecurityGroups(ctx, opts))
+}
@@ -0,0 +1,89 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/client/types"
+)
+
+func (c *AceCloudClient) CreateBucket(ctx context.Context, bucketReq *types.BucketCreateRequest) (*types.Bucket, error) {
+	return do[types.Bucket](ctx, c, request{
+		method:  "POST",
+		service: ServiceObjectStorage,
+		path:    "/buckets",
+		body:    bucketReq,
+		action:  "create bucket",
+	})
+}
+
+func (c *AceCloudClient) GetBucket(ctx context.Context, name string) (*types.Bucket, error) {
+	return do[types.Bucket](ctx, c, request{
+		method:  "GET",
+		service: ServiceObjectStorage,
+		path:    "/buckets/" + url.PathEscape(name),
+		action:  "get bucket",
+	})
+}
+
+func (c *AceCloudClient) UpdateBucket(ctx context.Context, name string, bucketReq *types.BucketUpdateRequest) (*types.Bucket, error) {
+	return do[types.Bucket](ctx, c, request{
+		method:  "PUT",
+		service: ServiceObjectStorage,
+		path:    "/buckets/" + url.PathEscape(name),
+		body:    bucketReq,
+		action:  "update bucket",
+	})
+}
+
+// DeleteBucket deletes a bucket. When force is set the API empties the bucket
+// (including all object versions) before removing it.
+func (c *AceCloudClient) DeleteBucket(ctx context.Context, name string, force bool) error {
+	_, err := do[json.RawMessage](ctx, c, request{
+		method:  "DELETE",
+		service: ServiceObjectStorage,
+		path:    "/buckets/" + url.PathEscape(name),
+		query:   deleteBucketQuery(force),
+		action:  "delete bucket",
+	})
+	return err
+}
+
+func deleteBucketQuery(force bool) url.Values {
+	if !force {
+		return nil
+	}
+	return url.Values{"force": {"true"}}
+}
+
+// CreateObjectStorageCredentials issues a new S3-compatible access key pair.
+// The secret key is only present in this response.
+func (c *AceCloudClient) CreateObjectStorageCredentials(ctx context.Context, credReq *types.ObjectStorageCredentialsCreateRequest) (*types.ObjectStorageCredentials, error) {
+	return do[types.ObjectStorageCredentials](ctx, c, request{
+		method:  "POST",
+		service: ServiceObjectStorage,
+		path:    "/credentials",
+		body:    credReq,
+		action:  "create object storage credentials",
+	})
+}
+
+func (c *AceCloudClient) GetObjectStorageCredentials(ctx context.Context, id string) (*types.ObjectStorageCredentials, error) {
+	return do[types.ObjectStorageCredentials](ctx, c, request{
+		method:  "GET",
+		service: ServiceObjectStorage,
+		path:    "/credentials/" + url.PathEscape(id),
+		action:  "get object storage credentials",
+	})
+}
+
+func (c *AceCloudClient) DeleteObjectStorageCredentials(ctx context.Context, id string) error {
+	_, err := do[json.RawMessage](ctx, c, request{
+		method:  "DELETE",
+		service: ServiceObjectStorage,
+		path:    "/credentials/" + url.PathEscape(id),
+		action:  "delete object storage credentials",
+	})
+	return err
+}
@@ -0,0 +1,83 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/client/types"
+)
+
+func TestBucketLifecycle(t *testing.T) {
+	ctx := context.Background()
+	c := newRecordedClient(t)
+
+	bucket, err := c.CreateBucket(ctx, &types.BucketCreateRequest{
+		Name: "tf-rec-bucket",
+		ACL:  "private",
+		LifecycleRules: []types.BucketLifecycleRule{
+			{ID: "expire-logs", Enabled: true, Prefix: "logs/", ExpirationDays: 30},
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreateBucket: %s", err)
+	}
+	if bucket.Name != "tf-rec-bucket" || bucket.Endpoint == "" || len(bucket.LifecycleRules) != 1 {
+		t.Fatalf("CreateBucket returned %+v", bucket)
+	}
+
+	if _, err := c.GetBucket(ctx, bucket.Name); err != nil {
+		t.Errorf("GetBucket: %s", err)
+	}
+	updated, err := c.UpdateBucket(ctx, bucket.Name, &types.BucketUpdateRequest{
+		Versioning:     true,
+		ACL:            "private",
+		LifecycleRules: []types.BucketLifecycleRule{},
+		CORSRules: []types.BucketCORSRule{
+			{AllowedOrigins: []string{"https://example.com"}, AllowedMethods: []string{"GET"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("UpdateBucket: %s", err)
+	}
+	if !updated.Versioning || len(updated.LifecycleRules) != 0 || len(updated.CORSRules) != 1 {
+		t.Errorf("UpdateBucket returned %+v", updated)
+	}
+
+	if err := c.DeleteBucket(ctx, bucket.Name, true); err != nil {
+		t.Fatalf("DeleteBucket: %s", err)
+	}
+	if _, err := c.GetBucket(ctx, bucket.Name); !IsNotFound(err) {
+		t.Errorf("GetBucket after delete returned %v, want a 404", err)
+	}
+	if err := c.DeleteBucket(ctx, bucket.Name, false); !IsNotFound(err) {
+		t.Errorf("DeleteBucket of a deleted bucket returned %v, want a 404", err)
+	}
+}
+
+func TestObjectStorageCredentialsLifecycle(t *testing.T) {
+	ctx := context.Background()
+	c := newRecordedClient(t)
+
+	creds, err := c.CreateObjectStorageCredentials(ctx, &types.ObjectStorageCredentialsCreateRequest{Description: "tf-rec"})
+	if err != nil {
+		t.Fatalf("CreateObjectStorageCredentials: %s", err)
+	}
+	if creds.ID == "" || creds.AccessKey == "" || creds.SecretKey == "" || creds.S3Endpoint == "" {
+		t.Fatalf("CreateObjectStorageCredentials returned %+v", creds)
+	}
+
+	got, err := c.GetObjectStorageCredentials(ctx, creds.ID)
+	if err != nil {
+		t.Fatalf("GetObjectStorageCredentials: %s", err)
+	}
+	if got.SecretKey != "" {
+		t.Error("GetObjectStorageCredentials returned the secret key")
+	}
+
+	if err := c.DeleteObjectStorageCredentials(ctx, creds.ID); err != nil {
+		t.Fatalf("DeleteObjectStorageCredentials: %s", err)
+	}
+	if _, err := c.GetObjectStorageCredentials(ctx, creds.ID); !IsNotFound(err) {
+		t.Errorf("GetObjectStorageCredentials after delete returned %v, want a 404", err)
+	}
+}
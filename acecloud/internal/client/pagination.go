@@ -0,0 +1,160 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"net/url"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// DefaultPageSize is the number of items requested per page when neither
+	// the client nor the call specifies a page size.
+	DefaultPageSize = 100
+	// MaxPageSize is the largest page size accepted by the API.
+	MaxPageSize = 1000
+)
+
+// ListOptions tunes a paginated list call.
+type ListOptions struct {
+	// PageSize overrides the client's page size for this call.
+	PageSize int
+	// Filters are passed to the list endpoint as additional query parameters.
+	Filters url.Values
+}
+
+// listPage is the envelope returned by list endpoints. The API pages either by
+// cursor, returning pagination.next_cursor while more items remain, or by
+// offset/limit, optionally reporting pagination.total.
+type listPage[T any] struct {
+	envelope[[]T]
+	Pagination struct {
+		Total      int    `json:"total"`
+		NextCursor string `json:"next_cursor"`
+	} `json:"pagination"`
+}
+
+func (c *AceCloudClient) pageSize(opts *ListOptions) int {
+	size := c.PageSize
+	if opts != nil && opts.PageSize > 0 {
+		size = opts.PageSize
+	}
+	if size <= 0 {
+		size = DefaultPageSize
+	}
+	if size > MaxPageSize {
+		size = MaxPageSize
+	}
+	return size
+}
+
+// paginate returns an iterator over every item of a list endpoint, fetching
+// pages lazily. Iteration stops at the first error, which is yielded with a
+// zero item, or when ctx is cancelled between pages.
+func paginate[T any](ctx context.Context, c *AceCloudClient, svc Service, path, what string, opts *ListOptions) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+
+		limit := c.pageSize(opts)
+		action := "list " + what
+
+		offset, seen := 0, 0
+		cursor := ""
+		var previous []byte
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(zero, fmt.Errorf("failed to %s: %w", action, err))
+				return
+			}
+
+			query := url.Values{}
+			if opts != nil {
+				for k, vs := range opts.Filters {
+					query[k] = append(query[k], vs...)
+				}
+			}
+			query.Set("limit", strconv.Itoa(limit))
+			if cursor != "" {
+				query.Set("cursor", cursor)
+			} else {
+				query.Set("offset", strconv.Itoa(offset))
+			}
+
+			var page listPage[T]
+			requestID, err := c.send(ctx, request{
+				method:  "GET",
+				service: svc,
+				path:    path,
+				query:   query,
+				action:  action,
+			}, &page)
+			if err != nil {
+				yield(zero, fmt.Errorf("failed to %s: %w", action, err))
+				return
+			}
+
+			if page.Error {
+				yield(zero, fmt.Errorf("failed to %s: %w", action, &APIError{Message: page.Message, RequestID: requestID}))
+				return
+			}
+
+			// A server that ignores offset returns the same full page for
+			// every offset. Without a total, nothing else would end the loop.
+			if cursor == "" && len(page.Data) > 0 {
+				current, err := json.Marshal(page.Data)
+				if err != nil {
+					yield(zero, fmt.Errorf("failed to %s: %w", action, err))
+					return
+				}
+				if bytes.Equal(current, previous) {
+					tflog.Warn(ctx, fmt.Sprintf("AceCloud API returned the same page of %s for offset %d, stopping", what, offset), map[string]interface{}{
+						"request_id": requestID,
+					})
+					return
+				}
+				previous = current
+			}
+
+			for _, item := range page.Data {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			seen += len(page.Data)
+
+			switch {
+			case page.Pagination.NextCursor != "" && page.Pagination.NextCursor == cursor:
+				// The same cursor again would fetch this page forever.
+				return
+			case page.Pagination.NextCursor != "":
+				cursor = page.Pagination.NextCursor
+			case cursor != "":
+				// Cursor paging without a next cursor means this was the last page.
+				return
+			case len(page.Data) < limit:
+				return
+			case page.Pagination.Total > 0 && seen >= page.Pagination.Total:
+				return
+			default:
+				offset += len(page.Data)
+			}
+		}
+	}
+}
+
+// Collect drains a list iterator into a slice, returning the first error.
+func Collect[T any](seq iter.Seq2[T, error]) ([]T, error) {
+	var out []T
+	for item, err := range seq {
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, item)
+	}
+	return out, nil
+}
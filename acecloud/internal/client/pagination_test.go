@@ -0,0 +1,226 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// TestList calls every list endpoint, with a page size small enough that the
+// recordings span several pages.
+func TestList(t *testing.T) {
+	tests := []struct {
+		name string
+		list func(context.Context, *recordedClient, *ListOptions) (int, error)
+	}{
+		{"regions", func(ctx context.Context, c *recordedClient, opts *ListOptions) (int, error) {
+			items, err := c.ListRegions(ctx, opts)
+			return len(items), err
+		}},
+		{"availability_zones", func(ctx context.Context, c *recordedClient, opts *ListOptions) (int, error) {
+			items, err := c.ListAvailabilityZones(ctx, opts)
+			return len(items), err
+		}},
+		{"flavors", func(ctx context.Context, c *recordedClient, opts *ListOptions) (int, error) {
+			items, err := c.ListFlavors(ctx, opts)
+			return len(items), err
+		}},
+		{"images", func(ctx context.Context, c *recordedClient, opts *ListOptions) (int, error) {
+			items, err := c.ListImages(ctx, opts)
+			return len(items), err
+		}},
+		{"volume_types", func(ctx context.Context, c *recordedClient, opts *ListOptions) (int, error) {
+			items, err := c.ListVolumeTypes(ctx, opts)
+			return len(items), err
+		}},
+		{"networks", func(ctx context.Context, c *recordedClient, opts *ListOptions) (int, error) {
+			items, err := c.ListNetworks(ctx, opts)
+			return len(items), err
+		}},
+		{"security_groups", func(ctx context.Context, c *recordedClient, opts *ListOptions) (int, error) {
+			items, err := c.ListSecurityGroups(ctx, opts)
+			return len(items), err
+		}},
+		{"vms", func(ctx context.Context, c *recordedClient, opts *ListOptions) (int, error) {
+			items, err := c.ListVMs(ctx, opts)
+			return len(items), err
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newRecordedClient(t)
+
+			n, err := tt.list(context.Background(), c, &ListOptions{PageSize: 2})
+			if err != nil {
+				t.Fatalf("list %s: %s", tt.name, err)
+			}
+			if n == 0 {
+				t.Errorf("list %s returned nothing", tt.name)
+			}
+		})
+	}
+}
+
+// Offset paging reads until a short page.
+func TestListOffset(t *testing.T) {
+	var offsets []string
+	c := stubClient(func(req *http.Request) (*http.Response, error) {
+		offsets = append(offsets, req.URL.Query().Get("offset"))
+		switch req.URL.Query().Get("offset") {
+		case "0":
+			return respond(http.StatusOK, `{"data":[{"id":"f-1"},{"id":"f-2"}]}`)(req)
+		case "2":
+			return respond(http.StatusOK, `{"data":[{"id":"f-3"},{"id":"f-4"}]}`)(req)
+		default:
+			return respond(http.StatusOK, `{"data":[{"id":"f-5"}]}`)(req)
+		}
+	})
+
+	flavors, err := c.ListFlavors(context.Background(), &ListOptions{PageSize: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(flavors) != 5 || flavors[4].ID != "f-5" {
+		t.Errorf("listed %+v, want f-1 to f-5", flavors)
+	}
+	if want := []string{"0", "2", "4"}; !slices.Equal(offsets, want) {
+		t.Errorf("requested offsets %q, want %q", offsets, want)
+	}
+}
+
+// A reported total ends offset paging without an extra empty page.
+func TestListOffsetTotal(t *testing.T) {
+	requests := 0
+	c := stubClient(func(req *http.Request) (*http.Response, error) {
+		requests++
+		offset := req.URL.Query().Get("offset")
+		return respond(http.StatusOK, `{"data":[{"id":"f-`+offset+`a"},{"id":"f-`+offset+`b"}],"pagination":{"total":4}}`)(req)
+	})
+
+	flavors, err := c.ListFlavors(context.Background(), &ListOptions{PageSize: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(flavors) != 4 || requests != 2 {
+		t.Errorf("listed %d flavors in %d requests, want 4 in 2", len(flavors), requests)
+	}
+}
+
+func TestListCursor(t *testing.T) {
+	var cursors []string
+	c := stubClient(func(req *http.Request) (*http.Response, error) {
+		q := req.URL.Query()
+		cursors = append(cursors, q.Get("cursor"))
+		if q.Get("limit") != "1" || q.Get("region") != "test-region" || q.Get("name") != "web" {
+			t.Errorf("query = %v", q)
+		}
+		if q.Get("cursor") == "" {
+			return respond(http.StatusOK, `{"data":[{"id":"vm-1"}],"pagination":{"next_cursor":"c1"}}`)(req)
+		}
+		return respond(http.StatusOK, `{"data":[{"id":"vm-2"}]}`)(req)
+	})
+
+	vms, err := c.ListVMs(context.Background(), &ListOptions{PageSize: 1, Filters: map[string][]string{"name": {"web"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(vms) != 2 || vms[1].ID != "vm-2" {
+		t.Errorf("listed %+v, want vm-1 and vm-2", vms)
+	}
+	if want := []string{"", "c1"}; !slices.Equal(cursors, want) {
+		t.Errorf("requested cursors %q, want %q", cursors, want)
+	}
+}
+
+func TestListStopsEarly(t *testing.T) {
+	requests := 0
+	c := stubClient(func(req *http.Request) (*http.Response, error) {
+		requests++
+		offset := req.URL.Query().Get("offset")
+		return respond(http.StatusOK, `{"data":[{"id":"f-`+offset+`a"},{"id":"f-`+offset+`b"}]}`)(req)
+	})
+
+	n := 0
+	for _, err := range c.Flavors(context.Background(), &ListOptions{PageSize: 2}) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n++; n == 3 {
+			break
+		}
+	}
+	if n != 3 || requests != 2 {
+		t.Errorf("iterated over %d flavors in %d requests, want 3 in 2", n, requests)
+	}
+}
+
+func TestListError(t *testing.T) {
+	c := stubClient(respond(http.StatusOK, `{"error":true,"message":"region disabled"}`))
+
+	if _, err := c.ListFlavors(context.Background(), nil); err == nil || !strings.Contains(err.Error(), "region disabled") {
+		t.Errorf("ListFlavors error = %v, want the API message", err)
+	}
+}
+
+func TestPageSize(t *testing.T) {
+	c := NewAceCloudClient("", "", "", "")
+	for _, tc := range []struct {
+		client, call, want int
+	}{
+		{0, 0, DefaultPageSize},
+		{50, 0, 50},
+		{50, 10, 10},
+		{0, MaxPageSize + 1, MaxPageSize},
+	} {
+		c.PageSize = tc.client
+		if got := c.pageSize(&ListOptions{PageSize: tc.call}); got != tc.want {
+			t.Errorf("page size with client %d and call %d = %d, want %d", tc.client, tc.call, got, tc.want)
+		}
+	}
+}
+
+// A server that ignores offset and reports no total always returns a full
+// first page; the second, identical page ends the listing.
+func TestListOffsetIgnored(t *testing.T) {
+	var requests []string
+	c := stubClient(func(req *http.Request) (*http.Response, error) {
+		requests = append(requests, req.URL.Query().Get("offset"))
+		return respond(http.StatusOK, `{"data":[{"id":"f-1"},{"id":"f-2"}]}`)(req)
+	})
+
+	flavors, err := c.ListFlavors(context.Background(), &ListOptions{PageSize: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(flavors) != 2 || flavors[0].ID != "f-1" || flavors[1].ID != "f-2" {
+		t.Errorf("listed %+v, want f-1 and f-2 once", flavors)
+	}
+	if want := []string{"0", "2"}; !slices.Equal(requests, want) {
+		t.Errorf("requested offsets %q, want %q", requests, want)
+	}
+}
+
+func TestListRepeatedCursor(t *testing.T) {
+	var requests []string
+	c := stubClient(func(req *http.Request) (*http.Response, error) {
+		requests = append(requests, req.URL.Query().Get("offset"))
+		if req.URL.Query().Get("cursor") == "" {
+			return respond(http.StatusOK, `{"data":[{"id":"f-1"}],"pagination":{"next_cursor":"c1"}}`)(req)
+		}
+		return respond(http.StatusOK, `{"data":[{"id":"f-2"}],"pagination":{"next_cursor":"c1"}}`)(req)
+	})
+
+	flavors, err := c.ListFlavors(context.Background(), &ListOptions{PageSize: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(flavors) != 2 || flavors[1].ID != "f-2" {
+		t.Errorf("listed %+v, want f-1 and f-2", flavors)
+	}
+	if len(requests) != 2 {
+		t.Errorf("sent %d requests, want 2", len(requests))
+	}
+}
@@ -0,0 +1,17 @@
+package client
+
+import (
+	"context"
+	"iter"
+
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/client/types"
+)
+
+// Regions returns an iterator over the regions available to the account.
+func (c *AceCloudClient) Regions(ctx context.Context, opts *ListOptions) iter.Seq2[types.Region, error] {
+	return paginate[types.Region](ctx, c, ServiceIdentity, "/regions", "regions", opts)
+}
+
+func (c *AceCloudClient) ListRegions(ctx context.Context, opts *ListOptions) ([]types.Region, error) {
+	return Collect(c.Regions(ctx, opts))
+}
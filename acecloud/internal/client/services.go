@@ -0,0 +1,48 @@
+package client
+
+import "strings"
+
+// Service describes an AceCloud API service. API keys are scoped to services,
+// so every request names the service it targets.
+type Service struct {
+	// Key identifies the service in the provider's endpoints block.
+	Key string
+	// Name is sent in the x-api-key-service-name header.
+	Name string
+	// BasePath is appended to the client's base URL to reach the service
+	// when no endpoint override is configured.
+	BasePath string
+}
+
+var (
+	ServiceCompute       = Service{Key: "compute", Name: "ace_vm", BasePath: "/cloud"}
+	ServiceNetwork       = Service{Key: "network", Name: "ace_network", BasePath: "/cloud"}
+	ServiceBlockStorage  = Service{Key: "block_storage", Name: "ace_volume", BasePath: "/cloud"}
+	ServiceObjectStorage = Service{Key: "object_storage", Name: "ace_object_storage", BasePath: "/object-storage"}
+	ServiceDatabase      = Service{Key: "database", Name: "ace_dbaas", BasePath: "/dbaas"}
+	ServiceDNS           = Service{Key: "dns", Name: "ace_dns", BasePath: "/dns"}
+	ServiceKubernetes    = Service{Key: "kubernetes", Name: "ace_kubernetes", BasePath: "/kubernetes"}
+	ServiceIdentity      = Service{Key: "identity", Name: "ace_iam", BasePath: "/iam"}
+)
+
+// Services lists every service the client knows about.
+var Services = []Service{
+	ServiceCompute,
+	ServiceNetwork,
+	ServiceBlockStorage,
+	ServiceObjectStorage,
+	ServiceDatabase,
+	ServiceDNS,
+	ServiceKubernetes,
+	ServiceIdentity,
+}
+
+// endpoint returns the URL requests to svc are made against. An override in
+// c.Endpoints replaces both the base URL and the service's base path, which
+// lets on-prem or staging deployments serve a service from its own host.
+func (c *AceCloudClient) endpoint(svc Service) string {
+	if override := c.Endpoints[svc.Key]; override != "" {
+		return strings.TrimRight(override, "/")
+	}
+	return strings.TrimRight(c.BaseURL, "/") + svc.BasePath
+}
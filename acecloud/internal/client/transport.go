@@ -0,0 +1,92 @@
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// DefaultRequestTimeout bounds each API request, including reading the
+// response body.
+const DefaultRequestTimeout = 30 * time.Second
+
+// TransportConfig describes how the client connects to the API.
+type TransportConfig struct {
+	// CACertPEM holds certificates trusted in addition to the system's,
+	// e.g. an internal CA that signed an on-prem endpoint's certificate.
+	CACertPEM []byte
+	// ClientCertPEM and ClientKeyPEM are presented to endpoints that
+	// require mutual TLS. Both or neither must be set.
+	ClientCertPEM []byte
+	ClientKeyPEM  []byte
+	// Insecure disables verification of the server's certificate.
+	Insecure bool
+	// ProxyURL routes requests through a proxy. When empty the
+	// HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment variables apply.
+	ProxyURL string
+	// Timeout bounds each request. Zero means DefaultRequestTimeout.
+	Timeout time.Duration
+}
+
+// NewHTTPClient returns an HTTP client configured as cfg describes.
+func NewHTTPClient(cfg TransportConfig) (*http.Client, error) {
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: cfg.Insecure,
+	}
+
+	if len(cfg.CACertPEM) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(cfg.CACertPEM) {
+			return nil, errors.New("no PEM-encoded certificates found in the CA certificate")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	switch {
+	case len(cfg.ClientCertPEM) > 0 && len(cfg.ClientKeyPEM) > 0:
+		cert, err := tls.X509KeyPair(cfg.ClientCertPEM, cfg.ClientKeyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("invalid client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	case len(cfg.ClientCertPEM) > 0 || len(cfg.ClientKeyPEM) > 0:
+		return nil, errors.New("a client certificate and its key must be set together")
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+
+	if cfg.ProxyURL != "" {
+		proxy, err := url.Parse(cfg.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy URL: %w", err)
+		}
+		switch proxy.Scheme {
+		case "http", "https", "socks5":
+		default:
+			return nil, fmt.Errorf("invalid proxy URL %q: the scheme must be http, https or socks5", cfg.ProxyURL)
+		}
+		if proxy.Host == "" {
+			return nil, fmt.Errorf("invalid proxy URL %q: it has no host", cfg.ProxyURL)
+		}
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = DefaultRequestTimeout
+	}
+
+	return &http.Client{
+		Transport: transport,
+		Timeout:   timeout,
+	}, nil
+}
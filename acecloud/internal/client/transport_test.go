@@ -0,0 +1,239 @@
+package client
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"log"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testCA issues certificates for the TLS tests.
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newTestCA(t *testing.T) *testCA {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testCA{cert: cert, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+// issue returns a PEM-encoded certificate for 127.0.0.1 and its key.
+func (ca *testCA) issue(t *testing.T, usage x509.ExtKeyUsage) ([]byte, []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: "127.0.0.1"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1)},
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+// newTLSServer starts a server with a certificate issued by ca. With
+// clientCAs set it requires a client certificate signed by one of them.
+func newTLSServer(t *testing.T, ca *testCA, clientCAs *x509.CertPool) *httptest.Server {
+	t.Helper()
+	certPEM, keyPEM := ca.issue(t, x509.ExtKeyUsageServerAuth)
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	s.TLS = &tls.Config{Certificates: []tls.Certificate{cert}}
+	if clientCAs != nil {
+		s.TLS.ClientAuth = tls.RequireAndVerifyClientCert
+		s.TLS.ClientCAs = clientCAs
+	}
+	// Handshake failures are expected; keep them out of the test output.
+	s.Config.ErrorLog = log.New(io.Discard, "", 0)
+	s.StartTLS()
+	t.Cleanup(s.Close)
+	return s
+}
+
+func get(c *http.Client, url string) error {
+	resp, err := c.Get(url)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+func TestNewHTTPClientCA(t *testing.T) {
+	ca := newTestCA(t)
+	s := newTLSServer(t, ca, nil)
+
+	for _, tc := range []struct {
+		name    string
+		cfg     TransportConfig
+		wantErr string
+	}{
+		{"system roots", TransportConfig{}, "certificate signed by unknown authority"},
+		{"custom CA", TransportConfig{CACertPEM: ca.pem}, ""},
+		{"other CA", TransportConfig{CACertPEM: newTestCA(t).pem}, "certificate signed by unknown authority"},
+		{"insecure", TransportConfig{Insecure: true}, ""},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			c, err := NewHTTPClient(tc.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = get(c, s.URL)
+			switch {
+			case tc.wantErr == "" && err != nil:
+				t.Errorf("GET: %s", err)
+			case tc.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tc.wantErr)):
+				t.Errorf("GET returned %v, want %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewHTTPClientMutualTLS(t *testing.T) {
+	ca := newTestCA(t)
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(ca.cert)
+	s := newTLSServer(t, ca, clientCAs)
+
+	c, err := NewHTTPClient(TransportConfig{CACertPEM: ca.pem})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := get(c, s.URL); err == nil {
+		t.Error("GET without a client certificate succeeded")
+	}
+
+	certPEM, keyPEM := ca.issue(t, x509.ExtKeyUsageClientAuth)
+	c, err = NewHTTPClient(TransportConfig{CACertPEM: ca.pem, ClientCertPEM: certPEM, ClientKeyPEM: keyPEM})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := get(c, s.URL); err != nil {
+		t.Errorf("GET with a client certificate: %s", err)
+	}
+}
+
+func TestNewHTTPClientProxy(t *testing.T) {
+	var proxied string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.URL.String()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(proxy.Close)
+
+	c, err := NewHTTPClient(TransportConfig{ProxyURL: proxy.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := get(c, "http://api.acecloud.test/v1/instances"); err != nil {
+		t.Fatalf("GET: %s", err)
+	}
+	if proxied != "http://api.acecloud.test/v1/instances" {
+		t.Errorf("proxy received %q", proxied)
+	}
+}
+
+func TestNewHTTPClientTimeout(t *testing.T) {
+	c, err := NewHTTPClient(TransportConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Timeout != DefaultRequestTimeout {
+		t.Errorf("default timeout = %s, want %s", c.Timeout, DefaultRequestTimeout)
+	}
+
+	c, err = NewHTTPClient(TransportConfig{Timeout: 20 * time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}))
+	t.Cleanup(s.Close)
+	if err := get(c, s.URL); err == nil || !strings.Contains(err.Error(), "Client.Timeout exceeded") {
+		t.Errorf("GET of a slow server returned %v, want a timeout", err)
+	}
+}
+
+func TestNewHTTPClientErrors(t *testing.T) {
+	ca := newTestCA(t)
+	certPEM, keyPEM := ca.issue(t, x509.ExtKeyUsageClientAuth)
+	_, otherKeyPEM := ca.issue(t, x509.ExtKeyUsageClientAuth)
+
+	for _, tc := range []struct {
+		name    string
+		cfg     TransportConfig
+		wantErr string
+	}{
+		{"CA without certificates", TransportConfig{CACertPEM: []byte("not a certificate")}, "no PEM-encoded certificates"},
+		{"certificate without key", TransportConfig{ClientCertPEM: certPEM}, "must be set together"},
+		{"key without certificate", TransportConfig{ClientKeyPEM: keyPEM}, "must be set together"},
+		{"mismatched key", TransportConfig{ClientCertPEM: certPEM, ClientKeyPEM: otherKeyPEM}, "invalid client certificate"},
+		{"proxy scheme", TransportConfig{ProxyURL: "ftp://proxy.test"}, "scheme must be http, https or socks5"},
+		{"proxy without host", TransportConfig{ProxyURL: "http://"}, "has no host"},
+		{"unparsable proxy", TransportConfig{ProxyURL: "http://proxy.test:port"}, "invalid proxy URL"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewHTTPClient(tc.cfg)
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("NewHTTPClient returned %v, want %q", err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package types
+
+type DatabaseInstanceCreateRequest struct {
+	Name                string   `json:"name"`
+	Engine              string   `json:"engine"`
+	Version             string   `json:"version"`
+	Flavor              string   `json:"flavor"`
+	StorageSize         int      `json:"storage_size"`
+	StorageType         string   `json:"storage_type,omitempty"`
+	HighAvailability    bool     `json:"high_availability"`
+	BackupWindow        string   `json:"backup_window,omitempty"`
+	BackupRetentionDays int      `json:"backup_retention_days"`
+	AllowedNetworks     []string `json:"allowed_networks,omitempty"`
+	AdminUsername       string   `json:"admin_username,omitempty"`
+	AdminPassword       string   `json:"admin_password,omitempty"`
+}
+
+// DatabaseInstanceUpdateRequest carries the settings that can be changed in
+// place. Version and flavor changes are applied asynchronously.
+type DatabaseInstanceUpdateRequest struct {
+	Version             string   `json:"version"`
+	Flavor              string   `json:"flavor"`
+	StorageSize         int      `json:"storage_size"`
+	HighAvailability    bool     `json:"high_availability"`
+	BackupWindow        string   `json:"backup_window"`
+	BackupRetentionDays int      `json:"backup_retention_days"`
+	AllowedNetworks     []string `json:"allowed_networks"`
+}
+
+type DatabaseInstance struct {
+	ID                  string   `json:"id"`
+	Name                string   `json:"name"`
+	Engine              string   `json:"engine"`
+	Version             string   `json:"version"`
+	Flavor              string   `json:"flavor"`
+	StorageSize         int      `json:"storage_size"`
+	StorageType         string   `json:"storage_type"`
+	HighAvailability    bool     `json:"high_availability"`
+	BackupWindow        string   `json:"backup_window"`
+	BackupRetentionDays int      `json:"backup_retention_days"`
+	AllowedNetworks     []string `json:"allowed_networks"`
+	AdminUsername       string   `json:"admin_username"`
+	// AdminPassword is only returned by the create call when the API
+	// generated the password.
+	AdminPassword string `json:"admin_password"`
+	Status        string `json:"status"`
+	Host          string `json:"host"`
+	Port          int    `json:"port"`
+	CreatedAt     string `json:"created_at"`
+}
+
+type DatabaseUserCreateRequest struct {
+	Name     string `json:"name"`
+	Password string `json:"password"`
+}
+
+type DatabaseUserUpdateRequest struct {
+	Password string `json:"password"`
+}
+
+type DatabaseUser struct {
+	Name string `json:"name"`
+}
+
+type DatabaseCreateRequest struct {
+	Name      string `json:"name"`
+	Charset   string `json:"charset,omitempty"`
+	Collation string `json:"collation,omitempty"`
+}
+
+type Database struct {
+	Name      string `json:"name"`
+	Charset   string `json:"charset"`
+	Collation string `json:"collation"`
+}
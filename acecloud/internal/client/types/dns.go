@@ -0,0 +1,47 @@
+package types
+
+type DNSZoneCreateRequest struct {
+	Name        string `json:"name"`
+	Email       string `json:"email,omitempty"`
+	Description string `json:"description,omitempty"`
+	TTL         int    `json:"ttl"`
+}
+
+type DNSZoneUpdateRequest struct {
+	Email       string `json:"email"`
+	Description string `json:"description"`
+	TTL         int    `json:"ttl"`
+}
+
+type DNSZone struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Email       string   `json:"email"`
+	Description string   `json:"description"`
+	TTL         int      `json:"ttl"`
+	Serial      int      `json:"serial"`
+	Status      string   `json:"status"`
+	NameServers []string `json:"name_servers"`
+}
+
+// DNSRecordSet groups all records sharing a name and type within a zone.
+type DNSRecordSet struct {
+	ZoneID  string   `json:"zone_id"`
+	Name    string   `json:"name"`
+	Type    string   `json:"type"`
+	TTL     int      `json:"ttl"`
+	Records []string `json:"records"`
+	FQDN    string   `json:"fqdn"`
+}
+
+type DNSRecordSetCreateRequest struct {
+	Name    string   `json:"name"`
+	Type    string   `json:"type"`
+	TTL     int      `json:"ttl"`
+	Records []string `json:"records"`
+}
+
+type DNSRecordSetUpdateRequest struct {
+	TTL     int      `json:"ttl"`
+	Records []string `json:"records"`
+}
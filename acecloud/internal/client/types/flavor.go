@@ -0,0 +1,14 @@
+package types
+
+type Flavor struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	VCPUs       int    `json:"vcpus"`
+	// RAM is expressed in MB.
+	RAM int `json:"ram"`
+	// Disk is expressed in GB.
+	Disk     int    `json:"disk"`
+	GPUs     int    `json:"gpus"`
+	GPUModel string `json:"gpu_model"`
+}
@@ -0,0 +1,10 @@
+package types
+
+// CallerIdentity describes the account an API key belongs to.
+type CallerIdentity struct {
+	AccountID string `json:"account_id"`
+	ClientID  int    `json:"client_id"`
+	UserID    int    `json:"user_id"`
+	ProjectID string `json:"project_id"`
+	Email     string `json:"email"`
+}
@@ -0,0 +1,16 @@
+package types
+
+type Image struct {
+	ID         string   `json:"id"`
+	Name       string   `json:"name"`
+	Status     string   `json:"status"`
+	OSDistro   string   `json:"os_distro"`
+	OSVersion  string   `json:"os_version"`
+	Visibility string   `json:"visibility"`
+	Tags       []string `json:"tags"`
+	// MinDisk is expressed in GB and MinRAM in MB.
+	MinDisk   int    `json:"min_disk"`
+	MinRAM    int    `json:"min_ram"`
+	Size      int64  `json:"size"`
+	CreatedAt string `json:"created_at"`
+}
@@ -0,0 +1,18 @@
+package types
+
+type Network struct {
+	ID       string   `json:"id"`
+	Name     string   `json:"name"`
+	Status   string   `json:"status"`
+	Shared   bool     `json:"shared"`
+	External bool     `json:"external"`
+	Subnets  []string `json:"subnets"`
+	Tags     []string `json:"tags"`
+}
+
+type SecurityGroup struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Tags        []string `json:"tags"`
+}
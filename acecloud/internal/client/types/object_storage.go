@@ -0,0 +1,62 @@
+package types
+
+type BucketLifecycleRule struct {
+	ID                                 string `json:"id"`
+	Enabled                            bool   `json:"enabled"`
+	Prefix                             string `json:"prefix"`
+	ExpirationDays                     int    `json:"expiration_days,omitempty"`
+	NoncurrentVersionExpirationDays    int    `json:"noncurrent_version_expiration_days,omitempty"`
+	AbortIncompleteMultipartUploadDays int    `json:"abort_incomplete_multipart_upload_days,omitempty"`
+}
+
+type BucketCORSRule struct {
+	AllowedOrigins []string `json:"allowed_origins"`
+	AllowedMethods []string `json:"allowed_methods"`
+	AllowedHeaders []string `json:"allowed_headers,omitempty"`
+	ExposeHeaders  []string `json:"expose_headers,omitempty"`
+	MaxAgeSeconds  int      `json:"max_age_seconds,omitempty"`
+}
+
+type BucketCreateRequest struct {
+	Name           string                `json:"name"`
+	Versioning     bool                  `json:"versioning"`
+	ACL            string                `json:"acl"`
+	PublicAccess   bool                  `json:"public_access"`
+	LifecycleRules []BucketLifecycleRule `json:"lifecycle_rules,omitempty"`
+	CORSRules      []BucketCORSRule      `json:"cors_rules,omitempty"`
+}
+
+// BucketUpdateRequest carries the mutable bucket settings. Lists are always
+// sent so that removing every rule in configuration clears them remotely.
+type BucketUpdateRequest struct {
+	Versioning     bool                  `json:"versioning"`
+	ACL            string                `json:"acl"`
+	PublicAccess   bool                  `json:"public_access"`
+	LifecycleRules []BucketLifecycleRule `json:"lifecycle_rules"`
+	CORSRules      []BucketCORSRule      `json:"cors_rules"`
+}
+
+type Bucket struct {
+	Name           string                `json:"name"`
+	Versioning     bool                  `json:"versioning"`
+	ACL            string                `json:"acl"`
+	PublicAccess   bool                  `json:"public_access"`
+	LifecycleRules []BucketLifecycleRule `json:"lifecycle_rules"`
+	CORSRules      []BucketCORSRule      `json:"cors_rules"`
+	Endpoint       string                `json:"endpoint"`
+	CreatedAt      string                `json:"created_at"`
+}
+
+type ObjectStorageCredentialsCreateRequest struct {
+	Description string `json:"description,omitempty"`
+}
+
+type ObjectStorageCredentials struct {
+	ID          string `json:"id"`
+	Description string `json:"description"`
+	AccessKey   string `json:"access_key"`
+	// SecretKey is only returned by the create call.
+	SecretKey  string `json:"secret_key"`
+	S3Endpoint string `json:"s3_endpoint"`
+	CreatedAt  string `json:"created_at"`
+}
@@ -0,0 +1,8 @@
+package types
+
+type Region struct {
+	Name        string `json:"name"`
+	DisplayName string `json:"display_name"`
+	Status      string `json:"status"`
+	IsDefault   bool   `json:"is_default"`
+}
@@ -0,0 +1,17 @@
+package types
+
+// VMPassword is the generated administrator password of a VM.
+type VMPassword struct {
+	Password string `json:"password"`
+}
+
+// Kubeconfig holds the credentials of a Kubernetes cluster. Raw is a complete
+// kubeconfig document; the other fields repeat its contents for callers that
+// configure a Kubernetes client directly.
+type Kubeconfig struct {
+	Raw                  string `json:"kubeconfig"`
+	Host                 string `json:"host"`
+	ClusterCACertificate string `json:"cluster_ca_certificate"`
+	Token                string `json:"token"`
+	ExpiresAt            string `json:"expires_at"`
+}
@@ -22,60 +22,47 @@ type VolumeRequest struct {
 	BillingType string `json:"billing_type"`
 }
 
-type VMCreateResponse struct {
-	Error   bool   `json:"error"`
-	Message string `json:"message"`
-	Data    struct {
-		ID string `json:"id"`
-	} `json:"data"`
-}
-
 type ErrorResponse struct {
 	Message    string `json:"message"`
 	StatusCode int    `json:"statusCode"`
 }
 
 type AvailabilityZone struct {
-	Name string `json:"name"`
+	Name  string `json:"name"`
+	State string `json:"state"`
 }
 
-type VMGetResponse struct {
-	Error   bool   `json:"error"`
-	Message string `json:"message"`
-	Data    struct {
-		Key              string `json:"key"`
-		ID               string `json:"id"`
-		Name             string `json:"name"`
-		Status           string `json:"status"`
-		AvailabilityZone string `json:"availability_zone"`
-		// Addresses: public/private
-		Addresses struct {
-			Public []struct {
-				Version int    `json:"version"`
-				Addr    string `json:"addr"`
-				MacAddr string `json:"mac_addr"`
-				Name    string `json:"name"`
-				Type    string `json:"type"`
-			} `json:"public"`
-			Private []interface{} `json:"private"`
-		} `json:"addresses"`
-	} `json:"data"`
+type VM struct {
+	Key              string   `json:"key"`
+	ID               string   `json:"id"`
+	Name             string   `json:"name"`
+	Status           string   `json:"status"`
+	AvailabilityZone string   `json:"availability_zone"`
+	Flavor           string   `json:"flavor"`
+	Tags             []string `json:"tags"`
+	CreatedAt        string   `json:"created_at"`
+	// Addresses: public/private
+	Addresses struct {
+		Public []struct {
+			Version int    `json:"version"`
+			Addr    string `json:"addr"`
+			MacAddr string `json:"mac_addr"`
+			Name    string `json:"name"`
+			Type    string `json:"type"`
+		} `json:"public"`
+		Private []interface{} `json:"private"`
+	} `json:"addresses"`
 }
 
-type DeleteResponse struct {
-	Error   bool   `json:"error"`
-	Message string `json:"message"`
+// PublicIP returns the first public address of the VM, or an empty string if
+// it has none.
+func (vm VM) PublicIP() string {
+	if len(vm.Addresses.Public) > 0 {
+		return vm.Addresses.Public[0].Addr
+	}
+	return ""
 }
 
 type VMUpdateRequest struct {
 	Name string `json:"name"`
 }
-
-type VMUpdateResponse struct {
-	Error   bool   `json:"error"`
-	Message string `json:"message"`
-	Data    struct {
-		ID   string `json:"id"`
-		Name string `json:"name"`
-	} `json:"data"`
-}
@@ -0,0 +1,17 @@
+package client
+
+import (
+	"context"
+	"iter"
+
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/client/types"
+)
+
+// VolumeTypes returns an iterator over the volume types available in the region.
+func (c *AceCloudClient) VolumeTypes(ctx context.Context, opts *ListOptions) iter.Seq2[types.VolumeType, error] {
+	return paginate[types.VolumeType](ctx, c, ServiceBlockStorage, "/volume-types", "volume types", opts)
+}
+
+func (c *AceCloudClient) ListVolumeTypes(ctx context.Context, opts *ListOptions) ([]types.VolumeType, error) {
+	return Collect(c.VolumeTypes(ctx, opts))
+}
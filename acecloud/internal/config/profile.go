@@ -0,0 +1,168 @@
+// Package config reads the shared AceCloud configuration and credentials
+// files.
+//
+// Both files hold named profiles in INI form:
+//
+//	# ~/.acecloud/config
+//	[default]
+//	api_endpoint = https://api.acecloud.ai
+//	region       = ap-south-mum-1
+//	project_id   = 1234
+//
+//	[staging]
+//	api_endpoint = https://staging.api.acecloud.ai
+//
+//	# ~/.acecloud/credentials
+//	[default]
+//	api_key = ...
+//
+// Any key may appear in either file; a value in the credentials file wins.
+package config
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultProfile is used when no profile is selected.
+const DefaultProfile = "default"
+
+// Profile holds the settings of one named profile. Empty fields are unset.
+type Profile struct {
+	Name        string
+	APIEndpoint string
+	APIKey      string
+	Region      string
+	ProjectID   string
+}
+
+// DefaultConfigFile returns ~/.acecloud/config, or ACECLOUD_CONFIG_FILE when
+// set.
+func DefaultConfigFile() string {
+	return pathFromEnv("ACECLOUD_CONFIG_FILE", "config")
+}
+
+// DefaultCredentialsFile returns ~/.acecloud/credentials, or
+// ACECLOUD_SHARED_CREDENTIALS_FILE when set.
+func DefaultCredentialsFile() string {
+	return pathFromEnv("ACECLOUD_SHARED_CREDENTIALS_FILE", "credentials")
+}
+
+func pathFromEnv(env, name string) string {
+	if v := os.Getenv(env); v != "" {
+		return v
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".acecloud", name)
+}
+
+// ErrProfileNotFound is returned by LoadProfile when neither file defines the
+// requested profile.
+var ErrProfileNotFound = errors.New("profile not found")
+
+// LoadProfile reads profile name from the config and credentials files.
+// Missing files are ignored. Loading DefaultProfile never fails with
+// ErrProfileNotFound; an empty Profile is returned instead.
+func LoadProfile(name, configFile, credentialsFile string) (*Profile, error) {
+	if name == "" {
+		name = DefaultProfile
+	}
+
+	p := &Profile{Name: name}
+	found := false
+	for _, path := range []string{configFile, credentialsFile} {
+		if path == "" {
+			continue
+		}
+		sections, err := parseFile(path)
+		if err != nil {
+			return nil, err
+		}
+		values, ok := sections[name]
+		if !ok {
+			continue
+		}
+		found = true
+		p.apply(values)
+	}
+
+	if !found && name != DefaultProfile {
+		return nil, fmt.Errorf("%w: %q is not defined in %s or %s", ErrProfileNotFound, name, configFile, credentialsFile)
+	}
+	return p, nil
+}
+
+func (p *Profile) apply(values map[string]string) {
+	for k, v := range values {
+		switch k {
+		case "api_endpoint":
+			p.APIEndpoint = v
+		case "api_key":
+			p.APIKey = v
+		case "region":
+			p.Region = v
+		case "project_id":
+			p.ProjectID = v
+		}
+	}
+}
+
+// parseFile reads an INI file into sections of key/value pairs. Section
+// headers may be written as [name] or [profile name]. Lines starting with #
+// or ; are comments.
+func parseFile(path string) (map[string]map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	defer f.Close()
+
+	sections := map[string]map[string]string{}
+	var current map[string]string
+
+	scanner := bufio.NewScanner(f)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			name := strings.TrimSpace(line[1 : len(line)-1])
+			name = strings.TrimSpace(strings.TrimPrefix(name, "profile "))
+			if name == "" {
+				return nil, fmt.Errorf("%s:%d: empty section name", path, lineNo)
+			}
+			if sections[name] == nil {
+				sections[name] = map[string]string{}
+			}
+			current = sections[name]
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("%s:%d: expected key = value", path, lineNo)
+		}
+		if current == nil {
+			return nil, fmt.Errorf("%s:%d: %q is outside of a profile section", path, lineNo, strings.TrimSpace(key))
+		}
+		current[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	return sections, nil
+}
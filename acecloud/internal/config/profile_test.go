@@ -0,0 +1,156 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeFile writes content to name in a temporary directory and returns its
+// path.
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+const testConfig = `
+# Shared settings
+[default]
+api_endpoint = https://api.acecloud.ai
+region       = ap-south-mum-1
+project_id   = 1234
+
+; Profiles may also be written the way the AWS CLI does.
+[profile staging]
+api_endpoint = https://staging.api.acecloud.ai
+region=ap-south-del-1
+api_key = from-config
+unknown_key = ignored
+`
+
+const testCredentials = `
+[default]
+api_key = default-key
+
+[staging]
+api_key = staging-key
+`
+
+func TestLoadProfile(t *testing.T) {
+	configFile := writeFile(t, "config", testConfig)
+	credentialsFile := writeFile(t, "credentials", testCredentials)
+
+	for _, tc := range []struct {
+		name string
+		want Profile
+	}{
+		{"", Profile{Name: "default", APIEndpoint: "https://api.acecloud.ai", APIKey: "default-key", Region: "ap-south-mum-1", ProjectID: "1234"}},
+		{"default", Profile{Name: "default", APIEndpoint: "https://api.acecloud.ai", APIKey: "default-key", Region: "ap-south-mum-1", ProjectID: "1234"}},
+		// Profiles are not merged with default, and the credentials file
+		// wins over the config file.
+		{"staging", Profile{Name: "staging", APIEndpoint: "https://staging.api.acecloud.ai", APIKey: "staging-key", Region: "ap-south-del-1"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			p, err := LoadProfile(tc.name, configFile, credentialsFile)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if *p != tc.want {
+				t.Errorf("LoadProfile(%q) = %+v, want %+v", tc.name, *p, tc.want)
+			}
+		})
+	}
+}
+
+func TestLoadProfileSingleFile(t *testing.T) {
+	credentialsFile := writeFile(t, "credentials", "[ci]\napi_key = ci-key\nregion = ap-south-mum-1\n")
+
+	p, err := LoadProfile("ci", filepath.Join(t.TempDir(), "missing"), credentialsFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.APIKey != "ci-key" || p.Region != "ap-south-mum-1" {
+		t.Errorf("profile = %+v", *p)
+	}
+}
+
+func TestLoadProfileMissing(t *testing.T) {
+	dir := t.TempDir()
+	configFile := filepath.Join(dir, "config")
+	credentialsFile := filepath.Join(dir, "credentials")
+
+	// Without either file, the default profile is empty rather than an
+	// error, so that the provider can be configured from its arguments.
+	p, err := LoadProfile("", configFile, credentialsFile)
+	if err != nil {
+		t.Fatalf("LoadProfile of the default profile without files: %s", err)
+	}
+	if *p != (Profile{Name: DefaultProfile}) {
+		t.Errorf("profile = %+v, want an empty default profile", *p)
+	}
+
+	configFile = writeFile(t, "config", testConfig)
+	_, err = LoadProfile("production", configFile, credentialsFile)
+	if !errors.Is(err, ErrProfileNotFound) {
+		t.Fatalf("LoadProfile of an undefined profile returned %v, want ErrProfileNotFound", err)
+	}
+	if !strings.Contains(err.Error(), `"production" is not defined in `+configFile) {
+		t.Errorf("error = %q, want it to name the profile and files", err)
+	}
+}
+
+func TestLoadProfileParseErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{"empty section", "[default]\nregion = r\n[ ]\n", "config:3: empty section name"},
+		{"missing equals", "[default]\nregion r\n", "config:2: expected key = value"},
+		{"key outside section", "# header\nregion = r\n[default]\n", `config:2: "region" is outside of a profile section`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			configFile := writeFile(t, "config", tc.content)
+			_, err := LoadProfile("", configFile, "")
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("LoadProfile returned %v, want %q", err, tc.wantErr)
+			}
+		})
+	}
+
+	// A path that exists but cannot be read is an error, unlike a missing
+	// file.
+	_, err := LoadProfile("", t.TempDir(), "")
+	if err == nil || !strings.Contains(err.Error(), "failed to read") {
+		t.Errorf("LoadProfile of a directory returned %v", err)
+	}
+}
+
+func TestDefaultFiles(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("ACECLOUD_CONFIG_FILE", "")
+	t.Setenv("ACECLOUD_SHARED_CREDENTIALS_FILE", "")
+
+	if got, want := DefaultConfigFile(), filepath.Join(home, ".acecloud", "config"); got != want {
+		t.Errorf("DefaultConfigFile() = %q, want %q", got, want)
+	}
+	if got, want := DefaultCredentialsFile(), filepath.Join(home, ".acecloud", "credentials"); got != want {
+		t.Errorf("DefaultCredentialsFile() = %q, want %q", got, want)
+	}
+
+	t.Setenv("ACECLOUD_CONFIG_FILE", "/etc/acecloud/config")
+	t.Setenv("ACECLOUD_SHARED_CREDENTIALS_FILE", "/run/secrets/acecloud")
+	if got := DefaultConfigFile(); got != "/etc/acecloud/config" {
+		t.Errorf("DefaultConfigFile() with ACECLOUD_CONFIG_FILE = %q", got)
+	}
+	if got := DefaultCredentialsFile(); got != "/run/secrets/acecloud" {
+		t.Errorf("DefaultCredentialsFile() with ACECLOUD_SHARED_CREDENTIALS_FILE = %q", got)
+	}
+}
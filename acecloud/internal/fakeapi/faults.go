@@ -0,0 +1,94 @@
+package fakeapi
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Fault describes an error or delay injected into matching requests.
+type Fault struct {
+	// Method matches the request method. Empty matches every method.
+	Method string
+	// Path matches requests whose path starts with it, e.g.
+	// "/cloud/instances". Empty matches every path.
+	Path string
+
+	// Latency delays the response.
+	Latency time.Duration
+	// StatusCode is returned instead of the real response. Zero only
+	// applies Latency and lets the request through.
+	StatusCode int
+	// Message is the error message of the response. It defaults to the
+	// status text.
+	Message string
+	// RetryAfter sets the Retry-After header, in whole seconds.
+	RetryAfter time.Duration
+
+	// Times limits the fault to the next n matching requests. Zero applies
+	// it to every matching request until ClearFaults is called.
+	Times int
+}
+
+// Inject adds a fault. When several faults match a request, the oldest one
+// applies.
+func (s *Server) Inject(f Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, &f)
+}
+
+// ClearFaults removes every injected fault.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = nil
+}
+
+// matchFault returns the fault that applies to r and uses it up. s.mu must
+// be held.
+func (s *Server) matchFault(r *http.Request) *Fault {
+	for i, f := range s.faults {
+		if f.Method != "" && f.Method != r.Method {
+			continue
+		}
+		if !strings.HasPrefix(r.URL.Path, f.Path) {
+			continue
+		}
+		if f.Times > 0 {
+			f.Times--
+			if f.Times == 0 {
+				s.faults = append(s.faults[:i:i], s.faults[i+1:]...)
+			}
+		}
+		return f
+	}
+	return nil
+}
+
+// apply delays and answers r as the fault describes. It reports whether the
+// request should still be served normally.
+func (f *Fault) apply(w http.ResponseWriter, r *http.Request) bool {
+	if f.Latency > 0 {
+		select {
+		case <-time.After(f.Latency):
+		case <-r.Context().Done():
+			return false
+		}
+	}
+
+	if f.StatusCode == 0 {
+		return true
+	}
+
+	if f.RetryAfter > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(int(f.RetryAfter.Seconds())))
+	}
+	message := f.Message
+	if message == "" {
+		message = http.StatusText(f.StatusCode)
+	}
+	writeError(w, f.StatusCode, message)
+	return false
+}
@@ -0,0 +1,270 @@
+package fakeapi
+
+import (
+	"cmp"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/client/types"
+)
+
+// AddInstance stores vm as if it had been created in the default region and
+// project, keeping its status. An empty ID is assigned one. It returns the
+// stored instance.
+func (s *Server) AddInstance(vm types.VM) types.VM {
+	s.lock()
+	defer s.mu.Unlock()
+
+	if vm.ID == "" {
+		vm.ID = s.newID("vm")
+	}
+	if vm.Status == "" {
+		vm.Status = StatusActive
+	}
+	s.instances[vm.ID] = &instance{
+		scope:    scope{region: DefaultRegion, projectID: DefaultProjectID},
+		vm:       vm,
+		password: "fake-password-" + vm.ID,
+	}
+	return vm
+}
+
+// Instance returns an instance by ID, in any scope.
+func (s *Server) Instance(id string) (types.VM, bool) {
+	s.lock()
+	defer s.mu.Unlock()
+
+	inst, ok := s.instances[id]
+	if !ok {
+		return types.VM{}, false
+	}
+	return inst.vm, true
+}
+
+// Instances returns every instance, in any scope.
+func (s *Server) Instances() []types.VM {
+	s.lock()
+	defer s.mu.Unlock()
+
+	out := make([]types.VM, 0, len(s.instances))
+	for _, inst := range s.instances {
+		out = append(out, inst.vm)
+	}
+	slices.SortFunc(out, func(a, b types.VM) int { return compareIDs(a.ID, b.ID) })
+	return out
+}
+
+// UpdateInstance changes an instance behind the provider's back, to simulate
+// drift. It reports whether the instance exists.
+func (s *Server) UpdateInstance(id string, update func(vm *types.VM)) bool {
+	s.lock()
+	defer s.mu.Unlock()
+
+	inst, ok := s.instances[id]
+	if ok {
+		update(&inst.vm)
+	}
+	return ok
+}
+
+// RemoveInstance deletes an instance immediately, to simulate deletion
+// outside Terraform. It reports whether the instance existed.
+func (s *Server) RemoveInstance(id string) bool {
+	s.lock()
+	defer s.mu.Unlock()
+
+	_, ok := s.instances[id]
+	delete(s.instances, id)
+	return ok
+}
+
+// instanceInScope returns the instance id visible to r, writing a 404 if
+// there is none. s.mu must be held.
+func (s *Server) instanceInScope(w http.ResponseWriter, r *http.Request, id string) *instance {
+	inst, ok := s.instances[id]
+	if !ok || inst.scope != requestScope(r) {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("instance %s not found", id))
+		return nil
+	}
+	return inst
+}
+
+func (s *Server) listInstances(w http.ResponseWriter, r *http.Request) {
+	s.lock()
+	q := r.URL.Query()
+	sc := requestScope(r)
+	var vms []types.VM
+	for _, inst := range s.instances {
+		if inst.scope != sc {
+			continue
+		}
+		if v := q.Get("name"); v != "" && inst.vm.Name != v {
+			continue
+		}
+		if v := q.Get("status"); v != "" && inst.vm.Status != v {
+			continue
+		}
+		if v := q.Get("availability_zone"); v != "" && inst.vm.AvailabilityZone != v {
+			continue
+		}
+		if v := q.Get("flavor"); v != "" && inst.vm.Flavor != v {
+			continue
+		}
+		vms = append(vms, inst.vm)
+	}
+	s.mu.Unlock()
+
+	slices.SortFunc(vms, func(a, b types.VM) int { return compareIDs(a.ID, b.ID) })
+	writePage(w, r, vms)
+}
+
+// createInstance creates Count instances and returns the first. They start
+// in BUILD and become ACTIVE after the transition delay.
+func (s *Server) createInstance(w http.ResponseWriter, r *http.Request) {
+	var req types.VMCreateRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	switch {
+	case req.Name == "":
+		writeError(w, http.StatusBadRequest, "name is required")
+		return
+	case req.Flavor == "":
+		writeError(w, http.StatusBadRequest, "flavor is required")
+		return
+	case req.BootUUID == "":
+		writeError(w, http.StatusBadRequest, "boot_uuid is required")
+		return
+	case req.Count < 0:
+		writeError(w, http.StatusBadRequest, "count must not be negative")
+		return
+	}
+	count := max(req.Count, 1)
+
+	s.lock()
+	defer s.mu.Unlock()
+
+	readyAt := time.Now().Add(s.transitionDelay)
+	var first types.VM
+	for i := range count {
+		vm := types.VM{
+			ID:               s.newID("vm"),
+			Name:             req.Name,
+			Status:           StatusBuild,
+			AvailabilityZone: req.AvailabilityZone,
+			Flavor:           req.Flavor,
+			Key:              req.Key,
+			CreatedAt:        time.Now().UTC().Format(time.RFC3339),
+		}
+		if count > 1 {
+			vm.Name = fmt.Sprintf("%s-%d", req.Name, i+1)
+		}
+		vm.Addresses.Public = slices.Grow(vm.Addresses.Public, 1)[:1]
+		vm.Addresses.Public[0].Version = 4
+		vm.Addresses.Public[0].Addr = fmt.Sprintf("203.0.113.%d", s.nextID%254+1)
+
+		s.instances[vm.ID] = &instance{
+			scope:    requestScope(r),
+			vm:       vm,
+			password: "fake-password-" + vm.ID,
+			readyAt:  readyAt,
+		}
+		if i == 0 {
+			first = vm
+		}
+	}
+
+	writeData(w, http.StatusCreated, first)
+}
+
+func (s *Server) getInstance(w http.ResponseWriter, r *http.Request) {
+	s.lock()
+	defer s.mu.Unlock()
+
+	if inst := s.instanceInScope(w, r, r.PathValue("id")); inst != nil {
+		writeData(w, http.StatusOK, inst.vm)
+	}
+}
+
+func (s *Server) updateInstance(w http.ResponseWriter, r *http.Request) {
+	var req types.VMUpdateRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	if req.Name == "" {
+		writeError(w, http.StatusBadRequest, "name is required")
+		return
+	}
+
+	s.lock()
+	defer s.mu.Unlock()
+
+	if inst := s.instanceInScope(w, r, r.PathValue("id")); inst != nil {
+		inst.vm.Name = req.Name
+		writeData(w, http.StatusOK, inst.vm)
+	}
+}
+
+func (s *Server) getInstancePassword(w http.ResponseWriter, r *http.Request) {
+	s.lock()
+	defer s.mu.Unlock()
+
+	inst := s.instanceInScope(w, r, r.PathValue("id"))
+	if inst == nil {
+		return
+	}
+	if inst.vm.Status == StatusBuild {
+		writeError(w, http.StatusConflict, fmt.Sprintf("instance %s is not active yet", inst.vm.ID))
+		return
+	}
+	writeData(w, http.StatusOK, types.VMPassword{Password: inst.password})
+}
+
+// deleteInstances implements the bulk-delete endpoint. Instances move to
+// DELETING and disappear after the transition delay. It answers 404 when none
+// of the IDs exist.
+func (s *Server) deleteInstances(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Key    string   `json:"key"`
+		Values []string `json:"values"`
+	}
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	if req.Key != "id" || len(req.Values) == 0 {
+		writeError(w, http.StatusBadRequest, `body must be {"key":"id","values":[...]}`)
+		return
+	}
+
+	s.lock()
+	defer s.mu.Unlock()
+
+	goneAt := time.Now().Add(s.transitionDelay)
+	var deleted []string
+	for _, id := range req.Values {
+		inst, ok := s.instances[id]
+		if !ok || inst.scope != requestScope(r) {
+			continue
+		}
+		inst.vm.Status = StatusDeleting
+		if inst.goneAt.IsZero() || goneAt.Before(inst.goneAt) {
+			inst.goneAt = goneAt
+		}
+		deleted = append(deleted, id)
+	}
+	if len(deleted) == 0 {
+		writeError(w, http.StatusNotFound, "no matching instances found")
+		return
+	}
+	writeData(w, http.StatusOK, map[string][]string{"deleted": deleted})
+}
+
+// compareIDs orders generated IDs by creation.
+func compareIDs(a, b string) int {
+	if len(a) != len(b) {
+		return cmp.Compare(len(a), len(b))
+	}
+	return cmp.Compare(a, b)
+}
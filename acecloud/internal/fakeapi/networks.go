@@ -0,0 +1,103 @@
+package fakeapi
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/client/types"
+)
+
+// AddNetwork stores n as if it had been created in the default region and
+// project. An empty ID is assigned one and an empty status is ACTIVE. It
+// returns the stored network.
+func (s *Server) AddNetwork(n types.Network) types.Network {
+	s.lock()
+	defer s.mu.Unlock()
+
+	if n.ID == "" {
+		n.ID = s.newID("net")
+	}
+	if n.Status == "" {
+		n.Status = StatusActive
+	}
+	s.networks[n.ID] = &network{
+		scope: scope{region: DefaultRegion, projectID: DefaultProjectID},
+		net:   n,
+	}
+	return n
+}
+
+// networkInScope returns the network id visible to r, writing a 404 if there
+// is none. s.mu must be held.
+func (s *Server) networkInScope(w http.ResponseWriter, r *http.Request, id string) *network {
+	n, ok := s.networks[id]
+	if !ok || n.scope != requestScope(r) {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("network %s not found", id))
+		return nil
+	}
+	return n
+}
+
+func (s *Server) listNetworks(w http.ResponseWriter, r *http.Request) {
+	s.lock()
+	sc := requestScope(r)
+	var nets []types.Network
+	for _, n := range s.networks {
+		if n.scope == sc || (n.net.Shared && n.region == sc.region) {
+			nets = append(nets, n.net)
+		}
+	}
+	s.mu.Unlock()
+
+	slices.SortFunc(nets, func(a, b types.Network) int { return compareIDs(a.ID, b.ID) })
+	writePage(w, r, nets)
+}
+
+// createNetwork creates a network in BUILD. It becomes ACTIVE after the
+// transition delay.
+func (s *Server) createNetwork(w http.ResponseWriter, r *http.Request) {
+	var req types.Network
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	if req.Name == "" {
+		writeError(w, http.StatusBadRequest, "name is required")
+		return
+	}
+
+	s.lock()
+	defer s.mu.Unlock()
+
+	req.ID = s.newID("net")
+	req.Status = StatusBuild
+	s.networks[req.ID] = &network{
+		scope:   requestScope(r),
+		net:     req,
+		readyAt: time.Now().Add(s.transitionDelay),
+	}
+
+	writeData(w, http.StatusCreated, req)
+}
+
+func (s *Server) getNetwork(w http.ResponseWriter, r *http.Request) {
+	s.lock()
+	defer s.mu.Unlock()
+
+	if n := s.networkInScope(w, r, r.PathValue("id")); n != nil {
+		writeData(w, http.StatusOK, n.net)
+	}
+}
+
+func (s *Server) deleteNetwork(w http.ResponseWriter, r *http.Request) {
+	s.lock()
+	defer s.mu.Unlock()
+
+	n := s.networkInScope(w, r, r.PathValue("id"))
+	if n == nil {
+		return
+	}
+	delete(s.networks, n.net.ID)
+	writeData(w, http.StatusOK, n.net)
+}
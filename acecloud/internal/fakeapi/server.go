@@ -0,0 +1,335 @@
+// Package fakeapi is an in-memory fake of the AceCloud API for unit and
+// acceptance tests. It serves instances, volumes and networks over TLS,
+// moves them through their statuses asynchronously, and can inject faults
+// and latency into any request.
+//
+//	s := fakeapi.New()
+//	defer s.Close()
+//	c := client.NewAceCloudClient(s.URL, fakeapi.APIKey, fakeapi.DefaultRegion, fakeapi.DefaultProjectID)
+//	c.HTTPClient = s.Client()
+package fakeapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/client/types"
+)
+
+const (
+	// APIKey is the only API key the server accepts.
+	APIKey = "fake-api-key"
+	// DefaultRegion is the default region of the fake account.
+	DefaultRegion = "fake-region-1"
+	// DefaultProjectID is the project of the fake account.
+	DefaultProjectID = "fake-project"
+	// AccountID, ClientID and UserID are returned as the caller identity.
+	AccountID = "fake-account"
+	ClientID  = 1001
+	UserID    = 2001
+)
+
+// Statuses the fake moves objects through.
+const (
+	StatusBuild    = "BUILD"
+	StatusActive   = "ACTIVE"
+	StatusDeleting = "DELETING"
+
+	VolumeStatusCreating  = "creating"
+	VolumeStatusAvailable = "available"
+	VolumeStatusDeleting  = "deleting"
+)
+
+// Server is a running fake AceCloud API. Its URL is suitable for the
+// provider's api_endpoint; use Client for an HTTP client that trusts its
+// certificate.
+type Server struct {
+	*httptest.Server
+
+	mu              sync.Mutex
+	regions         []types.Region
+	transitionDelay time.Duration
+	nextID          int
+	instances       map[string]*instance
+	volumes         map[string]*volume
+	networks        map[string]*network
+	faults          []*Fault
+	requests        []Request
+}
+
+// scope is the region and project an object was created in. Objects are only
+// visible to requests for the same scope.
+type scope struct {
+	region    string
+	projectID string
+}
+
+type instance struct {
+	scope
+	vm       types.VM
+	password string
+	readyAt  time.Time
+	goneAt   time.Time
+}
+
+// Volume is a block storage volume as served by the fake.
+type Volume struct {
+	ID               string `json:"id"`
+	Name             string `json:"name"`
+	Size             int    `json:"size"`
+	VolumeType       string `json:"volume_type"`
+	AvailabilityZone string `json:"availability_zone"`
+	Status           string `json:"status"`
+}
+
+type volume struct {
+	scope
+	vol     Volume
+	readyAt time.Time
+	goneAt  time.Time
+}
+
+type network struct {
+	scope
+	net     types.Network
+	readyAt time.Time
+}
+
+// Request records a request received by the server.
+type Request struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Header http.Header
+}
+
+// New starts a fake API server. Close it when done.
+func New() *Server {
+	s := &Server{
+		regions: []types.Region{
+			{Name: DefaultRegion, DisplayName: "Fake Region 1", Status: "active", IsDefault: true},
+		},
+		instances: map[string]*instance{},
+		volumes:   map[string]*volume{},
+		networks:  map[string]*network{},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /iam/caller-identity", s.getCallerIdentity)
+	mux.HandleFunc("GET /iam/regions", s.listRegions)
+
+	mux.HandleFunc("GET /cloud/instances", s.listInstances)
+	mux.HandleFunc("POST /cloud/instances", s.createInstance)
+	mux.HandleFunc("DELETE /cloud/instances", s.deleteInstances)
+	mux.HandleFunc("GET /cloud/instances/{id}", s.getInstance)
+	mux.HandleFunc("PUT /cloud/instances/{id}", s.updateInstance)
+	mux.HandleFunc("GET /cloud/instances/{id}/password", s.getInstancePassword)
+
+	mux.HandleFunc("GET /cloud/volumes", s.listVolumes)
+	mux.HandleFunc("POST /cloud/volumes", s.createVolume)
+	mux.HandleFunc("GET /cloud/volumes/{id}", s.getVolume)
+	mux.HandleFunc("PUT /cloud/volumes/{id}", s.updateVolume)
+	mux.HandleFunc("DELETE /cloud/volumes/{id}", s.deleteVolume)
+
+	mux.HandleFunc("GET /cloud/networks", s.listNetworks)
+	mux.HandleFunc("POST /cloud/networks", s.createNetwork)
+	mux.HandleFunc("GET /cloud/networks/{id}", s.getNetwork)
+	mux.HandleFunc("DELETE /cloud/networks/{id}", s.deleteNetwork)
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("no route for %s %s", r.Method, r.URL.Path))
+	})
+
+	s.Server = httptest.NewTLSServer(s.middleware(mux))
+	return s
+}
+
+// SetTransitionDelay sets how long objects stay in their transitional
+// statuses. The default of zero makes every change visible on the next read.
+func (s *Server) SetTransitionDelay(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.transitionDelay = d
+}
+
+// SetRegions replaces the regions available to the fake account.
+func (s *Server) SetRegions(regions []types.Region) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.regions = regions
+}
+
+// Requests returns the requests received so far, oldest first.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// middleware records each request, applies injected faults and checks the
+// API key and scope parameters before passing the request on.
+func (s *Server) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.requests = append(s.requests, Request{
+			Method: r.Method,
+			Path:   r.URL.Path,
+			Query:  r.URL.Query(),
+			Header: r.Header.Clone(),
+		})
+		fault := s.matchFault(r)
+		s.mu.Unlock()
+
+		if fault != nil {
+			if !fault.apply(w, r) {
+				return
+			}
+		}
+
+		if r.Header.Get("x-ace-api-key") != APIKey {
+			writeError(w, http.StatusUnauthorized, "invalid API key")
+			return
+		}
+
+		if !strings.HasPrefix(r.URL.Path, "/iam/") {
+			region := r.URL.Query().Get("region")
+			if !s.knownRegion(region) {
+				writeError(w, http.StatusBadRequest, fmt.Sprintf("unknown region %q", region))
+				return
+			}
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) knownRegion(name string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, r := range s.regions {
+		if r.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) newID(prefix string) string {
+	s.nextID++
+	return fmt.Sprintf("%s-%06d", prefix, s.nextID)
+}
+
+// advance applies every status transition that is due.
+func (s *Server) advance(now time.Time) {
+	for id, inst := range s.instances {
+		switch {
+		case !inst.goneAt.IsZero() && !now.Before(inst.goneAt):
+			delete(s.instances, id)
+		case inst.vm.Status == StatusBuild && !now.Before(inst.readyAt):
+			inst.vm.Status = StatusActive
+		}
+	}
+	for id, vol := range s.volumes {
+		switch {
+		case !vol.goneAt.IsZero() && !now.Before(vol.goneAt):
+			delete(s.volumes, id)
+		case vol.vol.Status == VolumeStatusCreating && !now.Before(vol.readyAt):
+			vol.vol.Status = VolumeStatusAvailable
+		}
+	}
+	for _, n := range s.networks {
+		if n.net.Status == StatusBuild && !now.Before(n.readyAt) {
+			n.net.Status = StatusActive
+		}
+	}
+}
+
+// lock takes the server lock and applies due transitions. Handlers call it
+// before touching any object.
+func (s *Server) lock() {
+	s.mu.Lock()
+	s.advance(time.Now())
+}
+
+func requestScope(r *http.Request) scope {
+	q := r.URL.Query()
+	return scope{region: q.Get("region"), projectID: q.Get("project_id")}
+}
+
+func (s *Server) getCallerIdentity(w http.ResponseWriter, r *http.Request) {
+	writeData(w, http.StatusOK, types.CallerIdentity{
+		AccountID: AccountID,
+		ClientID:  ClientID,
+		UserID:    UserID,
+		ProjectID: DefaultProjectID,
+		Email:     "fake@example.com",
+	})
+}
+
+func (s *Server) listRegions(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	regions := append([]types.Region(nil), s.regions...)
+	s.mu.Unlock()
+
+	writePage(w, r, regions)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeData(w http.ResponseWriter, status int, data interface{}) {
+	writeJSON(w, status, map[string]interface{}{
+		"error":   false,
+		"message": "",
+		"data":    data,
+	})
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]interface{}{
+		"error":   true,
+		"message": message,
+	})
+}
+
+// writePage serves one offset/limit page of items.
+func writePage[T any](w http.ResponseWriter, r *http.Request, items []T) {
+	q := r.URL.Query()
+	offset, _ := strconv.Atoi(q.Get("offset"))
+	limit, err := strconv.Atoi(q.Get("limit"))
+	if err != nil || limit <= 0 {
+		limit = len(items)
+	}
+
+	page := []T{}
+	if offset < len(items) {
+		page = items[offset:min(offset+limit, len(items))]
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"error":   false,
+		"message": "",
+		"data":    page,
+		"pagination": map[string]int{
+			"total": len(items),
+		},
+	})
+}
+
+func decodeBody(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %s", err))
+		return false
+	}
+	return true
+}
@@ -0,0 +1,408 @@
+package fakeapi_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/client"
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/client/types"
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/fakeapi"
+)
+
+func newClient(t *testing.T) (*fakeapi.Server, *client.AceCloudClient) {
+	t.Helper()
+	s := fakeapi.New()
+	t.Cleanup(s.Close)
+
+	c := client.NewAceCloudClient(s.URL, fakeapi.APIKey, fakeapi.DefaultRegion, fakeapi.DefaultProjectID)
+	c.HTTPClient = s.Client()
+	return s, c
+}
+
+func createVM(t *testing.T, c *client.AceCloudClient, name string, count int) *types.VM {
+	t.Helper()
+	vm, err := c.CreateVM(context.Background(), &types.VMCreateRequest{
+		Name:     name,
+		Flavor:   "C.2x4",
+		BootUUID: "image-1",
+		Count:    count,
+	})
+	if err != nil {
+		t.Fatalf("CreateVM: %s", err)
+	}
+	return vm
+}
+
+func TestInstanceLifecycle(t *testing.T) {
+	ctx := context.Background()
+	s, c := newClient(t)
+	s.SetTransitionDelay(50 * time.Millisecond)
+
+	vm := createVM(t, c, "tf-acc-lifecycle", 1)
+	if vm.Status != fakeapi.StatusBuild {
+		t.Fatalf("status after create = %q, want %q", vm.Status, fakeapi.StatusBuild)
+	}
+
+	if _, err := c.GetVMPassword(ctx, vm.ID); err == nil {
+		t.Fatal("GetVMPassword succeeded while the instance was building")
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	got, err := c.GetVM(ctx, vm.ID)
+	if err != nil {
+		t.Fatalf("GetVM: %s", err)
+	}
+	if got.Status != fakeapi.StatusActive {
+		t.Fatalf("status after delay = %q, want %q", got.Status, fakeapi.StatusActive)
+	}
+	if got.PublicIP() == "" {
+		t.Error("instance has no public address")
+	}
+
+	if _, err := c.GetVMPassword(ctx, vm.ID); err != nil {
+		t.Errorf("GetVMPassword: %s", err)
+	}
+
+	updated, err := c.UpdateVM(ctx, vm.ID, &types.VMUpdateRequest{Name: "tf-acc-renamed"})
+	if err != nil {
+		t.Fatalf("UpdateVM: %s", err)
+	}
+	if updated.Name != "tf-acc-renamed" {
+		t.Errorf("name after update = %q", updated.Name)
+	}
+
+	if err := c.DeleteVMs(ctx, []string{vm.ID}); err != nil {
+		t.Fatalf("DeleteVMs: %s", err)
+	}
+	got, err = c.GetVM(ctx, vm.ID)
+	if err != nil {
+		t.Fatalf("GetVM while deleting: %s", err)
+	}
+	if got.Status != fakeapi.StatusDeleting {
+		t.Errorf("status after delete = %q, want %q", got.Status, fakeapi.StatusDeleting)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	if _, err := c.GetVM(ctx, vm.ID); !client.IsNotFound(err) {
+		t.Errorf("GetVM after delete: got %v, want a 404", err)
+	}
+	if err := c.DeleteVMs(ctx, []string{vm.ID}); !client.IsNotFound(err) {
+		t.Errorf("DeleteVMs of a deleted instance: got %v, want a 404", err)
+	}
+}
+
+func TestCreateCount(t *testing.T) {
+	s, c := newClient(t)
+
+	createVM(t, c, "tf-acc-count", 3)
+
+	var names []string
+	for _, vm := range s.Instances() {
+		names = append(names, vm.Name)
+	}
+	if got, want := strings.Join(names, ","), "tf-acc-count-1,tf-acc-count-2,tf-acc-count-3"; got != want {
+		t.Errorf("instances = %s, want %s", got, want)
+	}
+}
+
+func TestCreateValidation(t *testing.T) {
+	_, c := newClient(t)
+
+	_, err := c.CreateVM(context.Background(), &types.VMCreateRequest{Name: "tf-acc-invalid"})
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest {
+		t.Errorf("got %v, want a 400", err)
+	}
+}
+
+func TestPagination(t *testing.T) {
+	s, c := newClient(t)
+	for i := range 250 {
+		s.AddInstance(types.VM{Name: fmt.Sprintf("vm-%d", i)})
+	}
+
+	vms, err := c.ListVMs(context.Background(), &client.ListOptions{PageSize: 100})
+	if err != nil {
+		t.Fatalf("ListVMs: %s", err)
+	}
+	if len(vms) != 250 {
+		t.Errorf("got %d instances, want 250", len(vms))
+	}
+	if vms[0].Name != "vm-0" || vms[249].Name != "vm-249" {
+		t.Errorf("instances out of order: first %q, last %q", vms[0].Name, vms[249].Name)
+	}
+
+	var pages int
+	for _, r := range s.Requests() {
+		if r.Method == http.MethodGet && r.Path == "/cloud/instances" {
+			pages++
+		}
+	}
+	if pages != 3 {
+		t.Errorf("fetched %d pages, want 3", pages)
+	}
+}
+
+func TestScope(t *testing.T) {
+	ctx := context.Background()
+	s, c := newClient(t)
+	s.SetRegions([]types.Region{
+		{Name: fakeapi.DefaultRegion, IsDefault: true},
+		{Name: "fake-region-2"},
+	})
+
+	vm := createVM(t, c, "tf-acc-scope", 1)
+
+	if _, err := c.WithScope("", "other-project").GetVM(ctx, vm.ID); !client.IsNotFound(err) {
+		t.Errorf("GetVM from another project: got %v, want a 404", err)
+	}
+	if _, err := c.WithScope("fake-region-2", "").GetVM(ctx, vm.ID); !client.IsNotFound(err) {
+		t.Errorf("GetVM from another region: got %v, want a 404", err)
+	}
+
+	_, err := c.WithScope("unknown-region", "").GetVM(ctx, vm.ID)
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest {
+		t.Errorf("GetVM from an unknown region: got %v, want a 400", err)
+	}
+}
+
+func TestAuthentication(t *testing.T) {
+	s, _ := newClient(t)
+	c := client.NewAceCloudClient(s.URL, "wrong-key", fakeapi.DefaultRegion, fakeapi.DefaultProjectID)
+	c.HTTPClient = s.Client()
+
+	if _, err := c.GetCallerIdentity(context.Background()); !client.IsAuthError(err) {
+		t.Errorf("got %v, want an auth error", err)
+	}
+}
+
+func TestCallerIdentityAndRegions(t *testing.T) {
+	ctx := context.Background()
+	_, c := newClient(t)
+
+	identity, err := c.GetCallerIdentity(ctx)
+	if err != nil {
+		t.Fatalf("GetCallerIdentity: %s", err)
+	}
+	if identity.ClientID != fakeapi.ClientID || identity.UserID != fakeapi.UserID {
+		t.Errorf("identity = %+v", identity)
+	}
+
+	regions, err := c.ListRegions(ctx, nil)
+	if err != nil {
+		t.Fatalf("ListRegions: %s", err)
+	}
+	if len(regions) != 1 || regions[0].Name != fakeapi.DefaultRegion || !regions[0].IsDefault {
+		t.Errorf("regions = %+v", regions)
+	}
+}
+
+func TestFaults(t *testing.T) {
+	for _, status := range []int{
+		http.StatusNotFound,
+		http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusServiceUnavailable,
+	} {
+		t.Run(http.StatusText(status), func(t *testing.T) {
+			ctx := context.Background()
+			s, c := newClient(t)
+			vm := createVM(t, c, "tf-acc-fault", 1)
+
+			s.Inject(fakeapi.Fault{
+				Method:     http.MethodGet,
+				Path:       "/cloud/instances/",
+				StatusCode: status,
+				Times:      1,
+			})
+
+			_, err := c.GetVM(ctx, vm.ID)
+			var apiErr *client.APIError
+			if !errors.As(err, &apiErr) || apiErr.StatusCode != status {
+				t.Fatalf("first GetVM: got %v, want a %d", err, status)
+			}
+
+			if _, err := c.GetVM(ctx, vm.ID); err != nil {
+				t.Errorf("second GetVM: %s", err)
+			}
+		})
+	}
+}
+
+func TestFaultMatching(t *testing.T) {
+	ctx := context.Background()
+	s, c := newClient(t)
+	vm := createVM(t, c, "tf-acc-fault", 1)
+
+	s.Inject(fakeapi.Fault{Method: http.MethodDelete, StatusCode: http.StatusInternalServerError})
+
+	if _, err := c.GetVM(ctx, vm.ID); err != nil {
+		t.Errorf("GetVM was affected by a DELETE fault: %s", err)
+	}
+	for range 2 {
+		if err := c.DeleteVMs(ctx, []string{vm.ID}); err == nil {
+			t.Error("DeleteVMs succeeded despite the fault")
+		}
+	}
+
+	s.ClearFaults()
+	if err := c.DeleteVMs(ctx, []string{vm.ID}); err != nil {
+		t.Errorf("DeleteVMs after ClearFaults: %s", err)
+	}
+}
+
+func TestFaultRetryAfter(t *testing.T) {
+	s, _ := newClient(t)
+	s.Inject(fakeapi.Fault{StatusCode: http.StatusTooManyRequests, RetryAfter: 2 * time.Second})
+
+	resp, err := s.Client().Get(s.URL + "/iam/regions")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("status = %d", resp.StatusCode)
+	}
+	if got := resp.Header.Get("Retry-After"); got != "2" {
+		t.Errorf("Retry-After = %q, want 2", got)
+	}
+}
+
+func TestLatency(t *testing.T) {
+	s, c := newClient(t)
+	s.Inject(fakeapi.Fault{Latency: 200 * time.Millisecond})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := c.GetCallerIdentity(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("got %v, want a deadline error", err)
+	}
+	if elapsed := time.Since(start); elapsed > 150*time.Millisecond {
+		t.Errorf("request took %s; the latency did not respect cancellation", elapsed)
+	}
+
+	s.ClearFaults()
+	s.Inject(fakeapi.Fault{Latency: 30 * time.Millisecond})
+	start = time.Now()
+	if _, err := c.GetCallerIdentity(context.Background()); err != nil {
+		t.Fatalf("GetCallerIdentity: %s", err)
+	}
+	if elapsed := time.Since(start); elapsed < 30*time.Millisecond {
+		t.Errorf("request took %s, want at least 30ms", elapsed)
+	}
+}
+
+// call performs a raw API request for the endpoints the client has no
+// methods for.
+func call(t *testing.T, s *fakeapi.Server, method, path, body string, out interface{}) int {
+	t.Helper()
+	url := fmt.Sprintf("%s%s?region=%s&project_id=%s", s.URL, path, fakeapi.DefaultRegion, fakeapi.DefaultProjectID)
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("x-ace-api-key", fakeapi.APIKey)
+
+	resp, err := s.Client().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if out != nil {
+		env := struct {
+			Data interface{} `json:"data"`
+		}{Data: out}
+		if err := json.NewDecoder(resp.Body).Decode(&env); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return resp.StatusCode
+}
+
+func TestVolumes(t *testing.T) {
+	s, _ := newClient(t)
+	s.SetTransitionDelay(30 * time.Millisecond)
+
+	var vol fakeapi.Volume
+	if code := call(t, s, "POST", "/cloud/volumes", `{"name":"tf-acc-vol","size":10,"volume_type":"ssd"}`, &vol); code != http.StatusCreated {
+		t.Fatalf("create status = %d", code)
+	}
+	if vol.Status != fakeapi.VolumeStatusCreating {
+		t.Errorf("status after create = %q", vol.Status)
+	}
+
+	time.Sleep(40 * time.Millisecond)
+	call(t, s, "GET", "/cloud/volumes/"+vol.ID, "", &vol)
+	if vol.Status != fakeapi.VolumeStatusAvailable {
+		t.Errorf("status after delay = %q", vol.Status)
+	}
+
+	if code := call(t, s, "PUT", "/cloud/volumes/"+vol.ID, `{"size":5}`, nil); code != http.StatusBadRequest {
+		t.Errorf("shrink status = %d, want 400", code)
+	}
+	call(t, s, "PUT", "/cloud/volumes/"+vol.ID, `{"size":20}`, &vol)
+	if vol.Size != 20 {
+		t.Errorf("size after extend = %d", vol.Size)
+	}
+
+	var vols []fakeapi.Volume
+	call(t, s, "GET", "/cloud/volumes", "", &vols)
+	if len(vols) != 1 {
+		t.Errorf("listed %d volumes, want 1", len(vols))
+	}
+
+	call(t, s, "DELETE", "/cloud/volumes/"+vol.ID, "", &vol)
+	if vol.Status != fakeapi.VolumeStatusDeleting {
+		t.Errorf("status after delete = %q", vol.Status)
+	}
+	time.Sleep(40 * time.Millisecond)
+	if _, ok := s.Volume(vol.ID); ok {
+		t.Error("volume still exists after the transition delay")
+	}
+}
+
+func TestNetworks(t *testing.T) {
+	ctx := context.Background()
+	s, c := newClient(t)
+
+	var created types.Network
+	if code := call(t, s, "POST", "/cloud/networks", `{"name":"tf-acc-net"}`, &created); code != http.StatusCreated {
+		t.Fatalf("create status = %d", code)
+	}
+	s.AddNetwork(types.Network{Name: "public", Shared: true, External: true})
+
+	nets, err := c.WithScope("", "other-project").ListNetworks(ctx, nil)
+	if err != nil {
+		t.Fatalf("ListNetworks: %s", err)
+	}
+	if len(nets) != 1 || nets[0].Name != "public" {
+		t.Errorf("networks visible to another project = %+v, want only the shared one", nets)
+	}
+
+	nets, err = c.ListNetworks(ctx, nil)
+	if err != nil {
+		t.Fatalf("ListNetworks: %s", err)
+	}
+	if len(nets) != 2 || nets[0].Status != fakeapi.StatusActive {
+		t.Errorf("networks = %+v", nets)
+	}
+
+	if code := call(t, s, "DELETE", "/cloud/networks/"+created.ID, "", nil); code != http.StatusOK {
+		t.Errorf("delete status = %d", code)
+	}
+	if code := call(t, s, "GET", "/cloud/networks/"+created.ID, "", nil); code != http.StatusNotFound {
+		t.Errorf("get after delete status = %d, want 404", code)
+	}
+}
@@ -0,0 +1,129 @@
+package fakeapi
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+)
+
+// Volume returns a volume by ID, in any scope.
+func (s *Server) Volume(id string) (Volume, bool) {
+	s.lock()
+	defer s.mu.Unlock()
+
+	vol, ok := s.volumes[id]
+	if !ok {
+		return Volume{}, false
+	}
+	return vol.vol, true
+}
+
+// volumeInScope returns the volume id visible to r, writing a 404 if there is
+// none. s.mu must be held.
+func (s *Server) volumeInScope(w http.ResponseWriter, r *http.Request, id string) *volume {
+	vol, ok := s.volumes[id]
+	if !ok || vol.scope != requestScope(r) {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("volume %s not found", id))
+		return nil
+	}
+	return vol
+}
+
+func (s *Server) listVolumes(w http.ResponseWriter, r *http.Request) {
+	s.lock()
+	sc := requestScope(r)
+	var vols []Volume
+	for _, vol := range s.volumes {
+		if vol.scope == sc {
+			vols = append(vols, vol.vol)
+		}
+	}
+	s.mu.Unlock()
+
+	slices.SortFunc(vols, func(a, b Volume) int { return compareIDs(a.ID, b.ID) })
+	writePage(w, r, vols)
+}
+
+// createVolume creates a volume in the creating status. It becomes available
+// after the transition delay.
+func (s *Server) createVolume(w http.ResponseWriter, r *http.Request) {
+	var req Volume
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	switch {
+	case req.Name == "":
+		writeError(w, http.StatusBadRequest, "name is required")
+		return
+	case req.Size <= 0:
+		writeError(w, http.StatusBadRequest, "size must be positive")
+		return
+	}
+
+	s.lock()
+	defer s.mu.Unlock()
+
+	req.ID = s.newID("vol")
+	req.Status = VolumeStatusCreating
+	s.volumes[req.ID] = &volume{
+		scope:   requestScope(r),
+		vol:     req,
+		readyAt: time.Now().Add(s.transitionDelay),
+	}
+
+	writeData(w, http.StatusCreated, req)
+}
+
+func (s *Server) getVolume(w http.ResponseWriter, r *http.Request) {
+	s.lock()
+	defer s.mu.Unlock()
+
+	if vol := s.volumeInScope(w, r, r.PathValue("id")); vol != nil {
+		writeData(w, http.StatusOK, vol.vol)
+	}
+}
+
+// updateVolume renames or extends a volume. Volumes cannot shrink.
+func (s *Server) updateVolume(w http.ResponseWriter, r *http.Request) {
+	var req Volume
+	if !decodeBody(w, r, &req) {
+		return
+	}
+
+	s.lock()
+	defer s.mu.Unlock()
+
+	vol := s.volumeInScope(w, r, r.PathValue("id"))
+	if vol == nil {
+		return
+	}
+	if req.Size != 0 && req.Size < vol.vol.Size {
+		writeError(w, http.StatusBadRequest, "volumes cannot be shrunk")
+		return
+	}
+	if req.Name != "" {
+		vol.vol.Name = req.Name
+	}
+	if req.Size != 0 {
+		vol.vol.Size = req.Size
+	}
+	writeData(w, http.StatusOK, vol.vol)
+}
+
+// deleteVolume moves a volume to deleting. It disappears after the
+// transition delay.
+func (s *Server) deleteVolume(w http.ResponseWriter, r *http.Request) {
+	s.lock()
+	defer s.mu.Unlock()
+
+	vol := s.volumeInScope(w, r, r.PathValue("id"))
+	if vol == nil {
+		return
+	}
+	vol.vol.Status = VolumeStatusDeleting
+	if goneAt := time.Now().Add(s.transitionDelay); vol.goneAt.IsZero() || goneAt.Before(vol.goneAt) {
+		vol.goneAt = goneAt
+	}
+	writeData(w, http.StatusOK, vol.vol)
+}
@@ -1,26 +1,34 @@
 package helpers
 
 import (
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
 	"fmt"
-)
+	"strings"
 
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/client"
+)
 
+// IsNotFoundError reports whether err says that a resource does not exist:
+// an HTTP 404, or an error envelope whose message says so. Only the API's
+// message is matched, never the formatted error, which also carries a random
+// request ID.
 func IsNotFoundError(err error) bool {
-	if err == nil {
+	if client.IsNotFound(err) {
+		return true
+	}
+
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != 0 {
 		return false
 	}
-	
-	errorStr := err.Error()
-	return containsAny(errorStr, []string{
+	return containsAny(apiErr.Message, []string{
 		"not found",
-		"404",
 		"does not exist",
-		"resource not found",
-		"VM not found",
 	})
 }
 
-
 func ConvertToInt(val interface{}) (int, error) {
 	switch v := val.(type) {
 	case int:
@@ -43,7 +51,6 @@ func StringInSlice(str string, list []string) bool {
 	return false
 }
 
-
 func InterfaceSliceToStringSlice(ifaceSlice []interface{}) []string {
 	strSlice := make([]string, len(ifaceSlice))
 	for i, v := range ifaceSlice {
@@ -52,6 +59,36 @@ func InterfaceSliceToStringSlice(ifaceSlice []interface{}) []string {
 	return strSlice
 }
 
+// ParseCompositeID splits an ID of the form "a/b/..." into exactly n parts.
+// IDs with more parts are rejected rather than folded into the last one.
+func ParseCompositeID(id string, n int) ([]string, error) {
+	parts := strings.Split(id, "/")
+	if len(parts) != n {
+		return nil, fmt.Errorf("unexpected ID format %q, expected %d parts separated by \"/\"", id, n)
+	}
+	for _, p := range parts {
+		if p == "" {
+			return nil, fmt.Errorf("unexpected ID format %q, parts must not be empty", id)
+		}
+	}
+	return parts, nil
+}
+
+// BuildCompositeID joins parts into an ID understood by ParseCompositeID.
+func BuildCompositeID(parts ...string) string {
+	return strings.Join(parts, "/")
+}
+
+// HashStrings returns a stable identifier for a list of strings. It is used as
+// the ID of data sources that return a list of objects.
+func HashStrings(strs []string) string {
+	h := sha1.New()
+	for _, s := range strs {
+		h.Write([]byte(s))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
 
 func containsAny(s string, substrs []string) bool {
 	for _, substr := range substrs {
@@ -72,4 +109,4 @@ func contains(s, substr string) bool {
 		}
 	}
 	return false
-}
\ No newline at end of file
+}
@@ -0,0 +1,94 @@
+package helpers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestParseCompositeID(t *testing.T) {
+	for _, tc := range []struct {
+		id      string
+		n       int
+		want    []string
+		wantErr bool
+	}{
+		{id: "db-1/app", n: 2, want: []string{"db-1", "app"}},
+		{id: "zone-1/www/A", n: 3, want: []string{"zone-1", "www", "A"}},
+		{id: "db-1", n: 2, wantErr: true},
+		{id: "db-1/", n: 2, wantErr: true},
+		{id: "/app", n: 2, wantErr: true},
+		{id: "db-1/app/extra", n: 2, wantErr: true},
+		{id: "zone-1/www/A/extra", n: 3, wantErr: true},
+	} {
+		got, err := ParseCompositeID(tc.id, tc.n)
+		if (err != nil) != tc.wantErr || !slices.Equal(got, tc.want) {
+			t.Errorf("ParseCompositeID(%q, %d) = %q, %v", tc.id, tc.n, got, err)
+		}
+	}
+	if id := BuildCompositeID("db-1", "app"); id != "db-1/app" {
+		t.Errorf("BuildCompositeID = %q", id)
+	}
+}
+
+func TestIsNotFoundError(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"404", &client.APIError{StatusCode: http.StatusNotFound, Message: "gone"}, true},
+		{"wrapped 404", fmt.Errorf("failed to get VM: %w", &client.APIError{StatusCode: http.StatusNotFound}), true},
+		{"envelope not found", &client.APIError{Message: "VM not found"}, true},
+		{"envelope does not exist", &client.APIError{Message: "zone does not exist"}, true},
+		{"envelope other error", &client.APIError{Message: "quota exceeded"}, false},
+		// Neither a "404" in the request ID nor "not found" in the message
+		// of another status makes an error a not-found.
+		{"500 with 404 in request ID", &client.APIError{StatusCode: http.StatusInternalServerError, Message: "internal error", RequestID: "4040a1b2-0000-4000-8000-000000000404"}, false},
+		{"403 mentioning not found", &client.APIError{StatusCode: http.StatusForbidden, Message: "policy not found for key"}, false},
+		{"transport error", fmt.Errorf("request failed: dial tcp: lookup api.404.test: no such host (request ID: 404)"), false},
+		{"plain error", errors.New("resource not found"), false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := IsNotFoundError(tc.err); got != tc.want {
+				t.Errorf("IsNotFoundError(%v) = %t, want %t", tc.err, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestImportStateScoped(t *testing.T) {
+	for _, tc := range []struct {
+		id                              string
+		wantID, wantRegion, wantProject string
+		wantErr                         bool
+	}{
+		{id: "db-1/app", wantID: "db-1/app"},
+		{id: "ap-south-mum-1/1234/db-1/app", wantID: "db-1/app", wantRegion: "ap-south-mum-1", wantProject: "1234"},
+		// Anything else is left for Read to reject.
+		{id: "1234/db-1/app", wantID: "1234/db-1/app"},
+		{id: "ap-south-mum-1//db-1/app", wantErr: true},
+	} {
+		d := schema.TestResourceDataRaw(t, map[string]*schema.Schema{
+			"region":     RegionSchema(),
+			"project_id": ProjectIDSchema(),
+		}, map[string]interface{}{})
+		d.SetId(tc.id)
+
+		_, err := ImportStateScoped(2)(context.Background(), d, nil)
+		if (err != nil) != tc.wantErr {
+			t.Errorf("import of %q: error %v, want error %t", tc.id, err, tc.wantErr)
+			continue
+		}
+		if err == nil && (d.Id() != tc.wantID || d.Get("region") != tc.wantRegion || d.Get("project_id") != tc.wantProject) {
+			t.Errorf("import of %q = ID %q, region %q, project_id %q", tc.id, d.Id(), d.Get("region"), d.Get("project_id"))
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package helpers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// RegionSchema is the optional per-resource region override. It defaults to
+// the provider's region and is recorded in state.
+func RegionSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Computed:    true,
+		ForceNew:    true,
+		Description: "Region the resource is managed in. Defaults to the provider region",
+	}
+}
+
+// ProjectIDSchema is the optional per-resource project override. It defaults
+// to the provider's project_id and is recorded in state.
+func ProjectIDSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Computed:    true,
+		ForceNew:    true,
+		Description: "Project the resource is managed in. Defaults to the provider project_id",
+	}
+}
+
+// DataSourceRegionSchema is the region override for data sources.
+func DataSourceRegionSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Computed:    true,
+		Description: "Region to read from. Defaults to the provider region",
+	}
+}
+
+// DataSourceProjectIDSchema is the project override for data sources.
+func DataSourceProjectIDSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Computed:    true,
+		Description: "Project to read from. Defaults to the provider project_id",
+	}
+}
+
+// ScopedClient returns the provider client, scoped to the region and
+// project_id set on d when they differ from the provider defaults.
+func ScopedClient(d *schema.ResourceData, meta interface{}) *client.AceCloudClient {
+	c := meta.(*client.AceCloudClient)
+	region, _ := d.Get("region").(string)
+	projectID, _ := d.Get("project_id").(string)
+	return c.WithScope(region, projectID)
+}
+
+// SetScope records the region and project_id c is scoped to in d.
+func SetScope(d *schema.ResourceData, c *client.AceCloudClient) {
+	_ = d.Set("region", c.Region)
+	_ = d.Set("project_id", c.ProjectID)
+}
+
+// ImportStateScoped imports a resource whose ID has n parts. The ID may be
+// prefixed with "<region>/<project_id>/" to import a resource outside the
+// provider's region and project; without the prefix the provider defaults
+// apply.
+func ImportStateScoped(n int) schema.StateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+		parts := strings.Split(d.Id(), "/")
+		if len(parts) == n+2 {
+			if parts[0] == "" || parts[1] == "" {
+				return nil, fmt.Errorf("unexpected ID format %q, region and project_id must not be empty", d.Id())
+			}
+			_ = d.Set("region", parts[0])
+			_ = d.Set("project_id", parts[1])
+			d.SetId(strings.Join(parts[2:], "/"))
+		}
+		return []*schema.ResourceData{d}, nil
+	}
+}
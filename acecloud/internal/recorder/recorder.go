@@ -0,0 +1,434 @@
+// Package recorder records HTTP interactions to cassette files and replays
+// them, so that client tests exercise real API responses without calling the
+// API on every run.
+//
+// In record mode a Recorder forwards each request to the API and appends the
+// exchange to its cassette, which Stop writes to disk. In replay mode it
+// answers each request with the first unused interaction that matches it, and
+// never touches the network. Cassettes are sanitized before they are written:
+// credentials, configured secret values and secret JSON fields are replaced
+// with Redacted.
+//
+//	rec, err := recorder.New("testdata/cassettes/TestGetVM.json", recorder.Options{Mode: recorder.ModeFromEnv()})
+//	c.HTTPClient = &http.Client{Transport: rec}
+//	defer rec.Stop()
+package recorder
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// EnvVar switches tests from replaying cassettes to recording them when set
+// to a non-empty value.
+const EnvVar = "ACECLOUD_RECORD"
+
+// Redacted replaces every secret in a cassette.
+const Redacted = "REDACTED"
+
+// Mode selects whether a Recorder calls the API or replays a cassette.
+type Mode int
+
+const (
+	// ModeReplay answers requests from an existing cassette.
+	ModeReplay Mode = iota
+	// ModeRecord forwards requests to the API and records them.
+	ModeRecord
+)
+
+// ModeFromEnv returns ModeRecord when EnvVar is set and ModeReplay otherwise.
+func ModeFromEnv() Mode {
+	if os.Getenv(EnvVar) != "" {
+		return ModeRecord
+	}
+	return ModeReplay
+}
+
+// sensitiveHeaders are redacted from every recorded request and response.
+var sensitiveHeaders = []string{
+	"Authorization",
+	"Cookie",
+	"Set-Cookie",
+	"X-Ace-Api-Key",
+}
+
+// volatileHeaders change between recordings, or no longer describe a body
+// once it has been sanitized, and are left out of cassettes.
+var volatileHeaders = []string{
+	"Content-Length",
+	"Date",
+	"X-Request-Id",
+}
+
+// Options configures a Recorder.
+type Options struct {
+	Mode Mode
+	// Transport performs requests in record mode. It defaults to
+	// http.DefaultTransport.
+	Transport http.RoundTripper
+	// Replacements maps values that must not appear in a cassette, such as
+	// the API key or the project ID of the recording account, to the values
+	// written in their place. Empty keys are ignored.
+	Replacements map[string]string
+	// RedactFields names JSON object fields whose non-empty string values
+	// are replaced with Redacted in request and response bodies, at any
+	// depth.
+	RedactFields []string
+}
+
+// Cassette is the recorded form of a sequence of HTTP interactions.
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is one recorded request and its response.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request is a recorded request. URL holds only the path and query, so that
+// a cassette can be replayed against any host.
+type Request struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   Body        `json:"body,omitempty"`
+}
+
+// Response is a recorded response.
+type Response struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       Body        `json:"body,omitempty"`
+}
+
+// Body is a recorded message body. JSON objects and arrays are stored as
+// JSON to keep cassettes readable; anything else is stored as a string.
+type Body string
+
+func (b Body) MarshalJSON() ([]byte, error) {
+	trimmed := bytes.TrimSpace([]byte(b))
+	if len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[') && json.Valid(trimmed) {
+		var buf bytes.Buffer
+		if err := json.Compact(&buf, trimmed); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+	return json.Marshal(string(b))
+}
+
+func (b *Body) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		*b = Body(s)
+		return nil
+	}
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, data); err != nil {
+		return err
+	}
+	*b = Body(buf.String())
+	return nil
+}
+
+// Recorder is an http.RoundTripper that records or replays a cassette. It is
+// safe for concurrent use.
+type Recorder struct {
+	path string
+	opts Options
+
+	mu           sync.Mutex
+	replacements map[string]string
+	cassette     Cassette
+	used         []bool
+}
+
+// New returns a Recorder for the cassette at path. In replay mode the
+// cassette must exist; in record mode it is overwritten by Stop.
+func New(path string, opts Options) (*Recorder, error) {
+	r := &Recorder{path: path, opts: opts, replacements: map[string]string{}}
+	if opts.Transport == nil {
+		r.opts.Transport = http.DefaultTransport
+	}
+	for old, placeholder := range opts.Replacements {
+		r.Replace(old, placeholder)
+	}
+
+	if opts.Mode == ModeReplay {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading cassette (set %s=1 to record it): %w", EnvVar, err)
+		}
+		if err := json.Unmarshal(data, &r.cassette); err != nil {
+			return nil, fmt.Errorf("parsing cassette %s: %w", path, err)
+		}
+		r.used = make([]bool, len(r.cassette.Interactions))
+	}
+	return r, nil
+}
+
+// Mode returns the mode the Recorder runs in.
+func (r *Recorder) Mode() Mode {
+	return r.opts.Mode
+}
+
+// Replace adds a replacement to those given in Options, for values only known
+// once a test is running.
+func (r *Recorder) Replace(old, placeholder string) {
+	if old == "" {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.replacements[old] = placeholder
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("recorder: reading request body: %w", err)
+		}
+	}
+	recorded := r.sanitizeRequest(req, body)
+
+	if r.opts.Mode == ModeReplay {
+		return r.replay(req, recorded)
+	}
+	return r.record(req, body, recorded)
+}
+
+func (r *Recorder) replay(req *http.Request, recorded Request) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, in := range r.cassette.Interactions {
+		if r.used[i] || !matches(in.Request, recorded) {
+			continue
+		}
+		r.used[i] = true
+		return in.Response.httpResponse(req), nil
+	}
+	return nil, fmt.Errorf("recorder: no unused interaction in %s matches %s %s", r.path, recorded.Method, recorded.URL)
+}
+
+func (r *Recorder) record(req *http.Request, body []byte, recorded Request) (*http.Response, error) {
+	out := req.Clone(req.Context())
+	if body != nil {
+		out.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	resp, err := r.opts.Transport.RoundTrip(out)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("recorder: reading response body: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	in := Interaction{
+		Request: recorded,
+		Response: Response{
+			StatusCode: resp.StatusCode,
+			Header:     r.sanitizeHeader(resp.Header),
+			Body:       Body(r.sanitizeBody(respBody)),
+		},
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cassette.Interactions = append(r.cassette.Interactions, in)
+	return resp, nil
+}
+
+// Stop finishes the recording. In record mode it writes the cassette; in
+// replay mode it returns an error if some interactions were never requested,
+// which means the code under test no longer makes the recorded calls.
+func (r *Recorder) Stop() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.opts.Mode == ModeReplay {
+		var unused []string
+		for i, in := range r.cassette.Interactions {
+			if !r.used[i] {
+				unused = append(unused, in.Request.Method+" "+in.Request.URL)
+			}
+		}
+		if len(unused) > 0 {
+			return fmt.Errorf("recorder: %d interactions in %s were not replayed: %s", len(unused), r.path, strings.Join(unused, ", "))
+		}
+		return nil
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(r.cassette); err != nil {
+		return fmt.Errorf("recorder: encoding cassette: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return fmt.Errorf("recorder: %w", err)
+	}
+	return os.WriteFile(r.path, buf.Bytes(), 0o644)
+}
+
+func (r *Recorder) sanitizeRequest(req *http.Request, body []byte) Request {
+	u := req.URL.EscapedPath()
+	if req.URL.RawQuery != "" {
+		u += "?" + req.URL.RawQuery
+	}
+	return Request{
+		Method: req.Method,
+		URL:    r.replace(u),
+		Header: r.sanitizeHeader(req.Header),
+		Body:   Body(r.sanitizeBody(body)),
+	}
+}
+
+func (r *Recorder) sanitizeHeader(h http.Header) http.Header {
+	if len(h) == 0 {
+		return nil
+	}
+	out := make(http.Header, len(h))
+	for k, vs := range h {
+		if slices.Contains(volatileHeaders, http.CanonicalHeaderKey(k)) {
+			continue
+		}
+		for _, v := range vs {
+			if slices.Contains(sensitiveHeaders, http.CanonicalHeaderKey(k)) {
+				v = Redacted
+			}
+			out.Add(k, r.replace(v))
+		}
+	}
+	return out
+}
+
+func (r *Recorder) sanitizeBody(body []byte) string {
+	if len(r.opts.RedactFields) > 0 && json.Valid(body) {
+		var v interface{}
+		dec := json.NewDecoder(bytes.NewReader(body))
+		dec.UseNumber()
+		if err := dec.Decode(&v); err == nil {
+			if redactFields(v, r.opts.RedactFields) {
+				if data, err := json.Marshal(v); err == nil {
+					body = data
+				}
+			}
+		}
+	}
+	return r.replace(string(body))
+}
+
+// replace applies the replacements, longest first so that a value
+// containing another is replaced as a whole. r.mu must not be held.
+func (r *Recorder) replace(s string) string {
+	r.mu.Lock()
+	olds := make([]string, 0, len(r.replacements))
+	for old := range r.replacements {
+		olds = append(olds, old)
+	}
+	slices.SortFunc(olds, func(a, b string) int { return len(b) - len(a) })
+	replacer := make([]string, 0, 2*len(olds))
+	for _, old := range olds {
+		replacer = append(replacer, old, r.replacements[old])
+	}
+	r.mu.Unlock()
+
+	return strings.NewReplacer(replacer...).Replace(s)
+}
+
+// redactFields replaces the non-empty string values of the named fields in
+// v, reporting whether it changed anything.
+func redactFields(v interface{}, fields []string) bool {
+	changed := false
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, val := range v {
+			if s, ok := val.(string); ok && s != "" && slices.Contains(fields, k) {
+				v[k] = Redacted
+				changed = true
+				continue
+			}
+			changed = redactFields(val, fields) || changed
+		}
+	case []interface{}:
+		for _, val := range v {
+			changed = redactFields(val, fields) || changed
+		}
+	}
+	return changed
+}
+
+// matches reports whether a recorded request answers got. Hosts and headers
+// are ignored, query parameters compare regardless of order and JSON bodies
+// compare by value.
+func matches(want, got Request) bool {
+	if want.Method != got.Method {
+		return false
+	}
+
+	wantURL, err1 := url.Parse(want.URL)
+	gotURL, err2 := url.Parse(got.URL)
+	if err1 != nil || err2 != nil {
+		return want.URL == got.URL
+	}
+	if wantURL.Path != gotURL.Path || !reflect.DeepEqual(wantURL.Query(), gotURL.Query()) {
+		return false
+	}
+
+	return bodiesEqual(want.Body, got.Body)
+}
+
+func bodiesEqual(a, b Body) bool {
+	if a == b {
+		return true
+	}
+	var av, bv interface{}
+	if json.Unmarshal([]byte(a), &av) != nil || json.Unmarshal([]byte(b), &bv) != nil {
+		return false
+	}
+	return reflect.DeepEqual(av, bv)
+}
+
+func (resp Response) httpResponse(req *http.Request) *http.Response {
+	header := resp.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", resp.StatusCode, http.StatusText(resp.StatusCode)),
+		StatusCode:    resp.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(string(resp.Body))),
+		ContentLength: int64(len(resp.Body)),
+		Request:       req,
+	}
+}
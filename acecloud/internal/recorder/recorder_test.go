@@ -0,0 +1,190 @@
+package recorder_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/recorder"
+)
+
+const (
+	apiKey    = "secret-api-key"
+	projectID = "project-1234"
+)
+
+func newAPI(t *testing.T) *httptest.Server {
+	t.Helper()
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=abc")
+		switch {
+		case r.Method == "POST":
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"data":{"id":"vm-1","project_id":"` + projectID + `","password":"hunter2","echo":` + string(body) + `}}`))
+		case r.URL.Path == "/missing":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte("not found"))
+		default:
+			_, _ = w.Write([]byte(`{"data":[{"id":"vm-1"}]}`))
+		}
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func call(t *testing.T, c *http.Client, method, url, body string) (int, string) {
+	t.Helper()
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("x-ace-api-key", apiKey)
+	resp, err := c.Do(req)
+	if err != nil {
+		t.Fatalf("%s %s: %s", method, url, err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp.StatusCode, string(data)
+}
+
+func record(t *testing.T, path string, opts recorder.Options) {
+	t.Helper()
+	api := newAPI(t)
+
+	opts.Mode = recorder.ModeRecord
+	rec, err := recorder.New(path, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := &http.Client{Transport: rec}
+
+	if code, body := call(t, c, "POST", api.URL+"/instances?project_id="+projectID+"&region=r1", `{"name":"vm","password":"p4ss"}`); code != http.StatusCreated || !strings.Contains(body, "hunter2") {
+		t.Fatalf("recorded POST returned %d %s, want the API's response", code, body)
+	}
+	call(t, c, "GET", api.URL+"/instances?region=r1&project_id="+projectID, "")
+	call(t, c, "GET", api.URL+"/missing", "")
+
+	if err := rec.Stop(); err != nil {
+		t.Fatalf("Stop: %s", err)
+	}
+}
+
+var sanitizeOpts = recorder.Options{
+	Replacements: map[string]string{projectID: "test-project", apiKey: recorder.Redacted},
+	RedactFields: []string{"password"},
+}
+
+func TestRecordSanitizes(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassettes", "test.json")
+	record(t, path, sanitizeOpts)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cassette := string(data)
+	for _, secret := range []string{apiKey, projectID, "hunter2", "p4ss", "session=abc", "127.0.0.1"} {
+		if strings.Contains(cassette, secret) {
+			t.Errorf("cassette contains %q:\n%s", secret, cassette)
+		}
+	}
+	for _, want := range []string{`"project_id": "test-project"`, `"password": "REDACTED"`, `"/instances?project_id=test-project&region=r1"`, `"body": "not found"`} {
+		if !strings.Contains(cassette, want) {
+			t.Errorf("cassette does not contain %s:\n%s", want, cassette)
+		}
+	}
+}
+
+func TestReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.json")
+	record(t, path, sanitizeOpts)
+
+	opts := sanitizeOpts
+	opts.Mode = recorder.ModeReplay
+	opts.Replacements = nil
+	rec, err := recorder.New(path, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := &http.Client{Transport: rec}
+
+	// The host is ignored, query parameters match in any order, JSON bodies
+	// match by value and redacted fields match whatever was sent.
+	code, body := call(t, c, "POST", "https://elsewhere.test/instances?region=r1&project_id=test-project", `{"password": "other", "name": "vm"}`)
+	if code != http.StatusCreated || !strings.Contains(body, `"id":"vm-1"`) {
+		t.Errorf("POST replayed %d %s", code, body)
+	}
+	if code, body := call(t, c, "GET", "https://elsewhere.test/missing", ""); code != http.StatusNotFound || body != "not found" {
+		t.Errorf("GET /missing replayed %d %q", code, body)
+	}
+
+	if err := rec.Stop(); err == nil || !strings.Contains(err.Error(), "1 interactions") {
+		t.Errorf("Stop with an unreplayed interaction returned %v", err)
+	}
+
+	if code, _ := call(t, c, "GET", "https://elsewhere.test/instances?region=r1&project_id=test-project", ""); code != http.StatusOK {
+		t.Errorf("GET replayed %d", code)
+	}
+	if err := rec.Stop(); err != nil {
+		t.Errorf("Stop: %s", err)
+	}
+}
+
+func TestReplayMismatch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.json")
+	record(t, path, sanitizeOpts)
+
+	rec, err := recorder.New(path, recorder.Options{Mode: recorder.ModeReplay})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := &http.Client{Transport: rec}
+
+	for _, tc := range []struct{ method, url, body string }{
+		{"DELETE", "https://api.test/missing", ""},
+		{"GET", "https://api.test/instances?region=r2&project_id=test-project", ""},
+		{"POST", "https://api.test/instances?region=r1&project_id=test-project", `{"name":"other"}`},
+	} {
+		req, _ := http.NewRequest(tc.method, tc.url, strings.NewReader(tc.body))
+		if _, err := c.Do(req); err == nil || !strings.Contains(err.Error(), "no unused interaction") {
+			t.Errorf("%s %s %s: err = %v, want no matching interaction", tc.method, tc.url, tc.body, err)
+		}
+	}
+
+	// Each interaction is replayed once.
+	req, _ := http.NewRequest("GET", "https://api.test/missing", nil)
+	if _, err := c.Do(req); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Do(req); err == nil {
+		t.Error("an interaction was replayed twice")
+	}
+}
+
+func TestMissingCassette(t *testing.T) {
+	_, err := recorder.New(filepath.Join(t.TempDir(), "none.json"), recorder.Options{})
+	if err == nil || !strings.Contains(err.Error(), recorder.EnvVar) {
+		t.Errorf("err = %v, want a hint to record the cassette", err)
+	}
+}
+
+func TestModeFromEnv(t *testing.T) {
+	t.Setenv(recorder.EnvVar, "")
+	if recorder.ModeFromEnv() != recorder.ModeReplay {
+		t.Error("mode without the variable is not replay")
+	}
+	t.Setenv(recorder.EnvVar, "1")
+	if recorder.ModeFromEnv() != recorder.ModeRecord {
+		t.Error("mode with the variable is not record")
+	}
+}
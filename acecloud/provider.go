@@ -1,44 +1,77 @@
 package acecloud
 
 import (
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"context"
+	"fmt"
+	"runtime"
+	"time"
+
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/datasources"
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/client"
 	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/resources"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
-func Provider() *schema.Provider {
-	var descriptions = map[string]string{
-		"api_endpoint": "The base URL for the AceCloud API endpoint.",
-		"api_key": "The API key used to authenticate with AceCloud services.",
-		"region": "The AceCloud region to deploy resources in.",
-		"project_id": "The project ID for organizing resources in AceCloud.",
-		"client_id": "The tenant/client ID for AceCloud account identification.",
-		"user_id": "The user ID for AceCloud account access.",
-	}
-	
-	return &schema.Provider{
+// descriptions is shared by the SDK and framework provider schemas, which must
+// match for the two to be muxed.
+var descriptions = map[string]string{
+	"api_endpoint":                "The base URL for the AceCloud API endpoint.",
+	"api_key":                     "The API key used to authenticate with AceCloud services.",
+	"profile":                     "The named profile in the shared config and credentials files (~/.acecloud/config and ~/.acecloud/credentials) to read settings from. Values set in the provider block or through environment variables take precedence over the profile.",
+	"region":                      "The AceCloud region to deploy resources in. Defaults to the account's default region and must be one of the regions available to the account.",
+	"project_id":                  "The project ID for organizing resources in AceCloud.",
+	"client_id":                   "The tenant/client ID for AceCloud account identification. When set, configuration fails if the API key belongs to a different tenant.",
+	"user_id":                     "The user ID for AceCloud account access. When set, configuration fails if the API key belongs to a different user.",
+	"list_page_size":              "The number of items requested per page when listing resources.",
+	"max_requests_per_second":     "The maximum rate of requests sent to each AceCloud service. The rate of a service is lowered while it answers with HTTP 429, and throttled requests are retried. Set to 0 to disable rate limiting.",
+	"max_concurrent_requests":     "The maximum number of API requests in flight at once, across all services. Set to 0 for no limit.",
+	"skip_credentials_validation": "Skip the API call that validates the credentials during provider configuration. Also skips the client_id and user_id checks.",
+	"endpoints":                   "Overrides the endpoint URL of individual AceCloud services, e.g. for on-prem or staging deployments.",
+	"ca_cert_file":                "Path to a PEM file of CA certificates to trust in addition to the system's, e.g. for an on-prem endpoint signed by an internal CA. Conflicts with ca_cert_pem.",
+	"ca_cert_pem":                 "PEM-encoded CA certificates to trust in addition to the system's. Conflicts with ca_cert_file.",
+	"client_cert":                 "PEM-encoded client certificate, or the path to a file containing it, presented to endpoints that require mutual TLS. Requires client_key.",
+	"client_key":                  "PEM-encoded private key of client_cert, or the path to a file containing it.",
+	"insecure":                    "Skip verification of the API endpoint's TLS certificate. Only use this for testing.",
+	"proxy_url":                   "URL of an HTTP, HTTPS or SOCKS5 proxy to reach the API through. Defaults to the HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment variables.",
+	"request_timeout":             "The maximum duration of each API request, as a Go duration such as \"30s\" or \"2m\".",
+}
+
+// Provider returns the SDK provider. version is the provider's release,
+// reported to the API in the User-Agent header.
+func Provider(version string) *schema.Provider {
+	p := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"api_endpoint": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("ACECLOUD_API_ENDPOINT", nil),
 				Description: descriptions["api_endpoint"],
 			},
 			"api_key": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				Sensitive:   true,
 				DefaultFunc: schema.EnvDefaultFunc("ACECLOUD_API_KEY", nil),
 				Description: descriptions["api_key"],
 			},
+			"profile": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ACECLOUD_PROFILE", nil),
+				Description: descriptions["profile"],
+			},
 			"region": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				Default:     "us-east-1",
+				DefaultFunc: schema.EnvDefaultFunc("ACECLOUD_REGION", nil),
 				Description: descriptions["region"],
 			},
 			"project_id": {
 				Type:        schema.TypeString,
 				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ACECLOUD_PROJECT_ID", nil),
 				Description: descriptions["project_id"],
 			},
 			"client_id": {
@@ -51,18 +84,160 @@ func Provider() *schema.Provider {
 				Optional:    true,
 				Description: descriptions["user_id"],
 			},
+			"list_page_size": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      client.DefaultPageSize,
+				Description:  descriptions["list_page_size"],
+				ValidateFunc: validation.IntBetween(1, client.MaxPageSize),
+			},
+			"max_requests_per_second": {
+				Type:         schema.TypeFloat,
+				Optional:     true,
+				Default:      client.DefaultRequestsPerSecond,
+				Description:  descriptions["max_requests_per_second"],
+				ValidateFunc: validation.FloatAtLeast(0),
+			},
+			"max_concurrent_requests": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      client.DefaultMaxConcurrentRequests,
+				Description:  descriptions["max_concurrent_requests"],
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"skip_credentials_validation": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: descriptions["skip_credentials_validation"],
+			},
+			"endpoints": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: descriptions["endpoints"],
+				Elem: &schema.Resource{
+					Schema: endpointsSchema(),
+				},
+			},
+			"ca_cert_file": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   descriptions["ca_cert_file"],
+				ConflictsWith: []string{"ca_cert_pem"},
+			},
+			"ca_cert_pem": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   descriptions["ca_cert_pem"],
+				ConflictsWith: []string{"ca_cert_file"},
+			},
+			"client_cert": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  descriptions["client_cert"],
+				RequiredWith: []string{"client_key"},
+			},
+			"client_key": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				Description:  descriptions["client_key"],
+				RequiredWith: []string{"client_cert"},
+			},
+			"insecure": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: descriptions["insecure"],
+			},
+			"proxy_url": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  descriptions["proxy_url"],
+				ValidateFunc: validation.IsURLWithScheme([]string{"http", "https", "socks5"}),
+			},
+			"request_timeout": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      client.DefaultRequestTimeout.String(),
+				Description:  descriptions["request_timeout"],
+				ValidateFunc: validatePositiveDuration,
+			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
-            "acecloud_vm": resources.ResourceAceCloudVM(),
+			"acecloud_vm":                         resources.ResourceAceCloudVM(),
+			"acecloud_object_storage_bucket":      resources.ResourceAceCloudObjectStorageBucket(),
+			"acecloud_object_storage_credentials": resources.ResourceAceCloudObjectStorageCredentials(),
+			"acecloud_database_instance":          resources.ResourceAceCloudDatabaseInstance(),
+			"acecloud_database_user":              resources.ResourceAceCloudDatabaseUser(),
+			"acecloud_database":                   resources.ResourceAceCloudDatabase(),
+			"acecloud_dns_zone":                   resources.ResourceAceCloudDNSZone(),
+			"acecloud_dns_record":                 resources.ResourceAceCloudDNSRecord(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-            // "acecloud_flavor": dataSourceAceCloudFlavor(),
-            // "acecloud_image": dataSourceAceCloudImage(),
-            // "acecloud_network": dataSourceAceCloudNetwork(),
-            // "acecloud_security_group": dataSourceAceCloudSecurityGroup(),
-            // "acecloud_volume_type": dataSourceAceCloudVolumeType(),
-            // "acecloud_availability_zone": dataSourceAceCloudAvailabilityZone(),
+			"acecloud_flavor":             datasources.DataSourceAceCloudFlavor(),
+			"acecloud_flavors":            datasources.DataSourceAceCloudFlavors(),
+			"acecloud_image":              datasources.DataSourceAceCloudImage(),
+			"acecloud_images":             datasources.DataSourceAceCloudImages(),
+			"acecloud_network":            datasources.DataSourceAceCloudNetwork(),
+			"acecloud_networks":           datasources.DataSourceAceCloudNetworks(),
+			"acecloud_security_group":     datasources.DataSourceAceCloudSecurityGroup(),
+			"acecloud_security_groups":    datasources.DataSourceAceCloudSecurityGroups(),
+			"acecloud_volume_type":        datasources.DataSourceAceCloudVolumeType(),
+			"acecloud_volume_types":       datasources.DataSourceAceCloudVolumeTypes(),
+			"acecloud_availability_zone":  datasources.DataSourceAceCloudAvailabilityZone(),
+			"acecloud_availability_zones": datasources.DataSourceAceCloudAvailabilityZones(),
+			"acecloud_vm":                 datasources.DataSourceAceCloudVM(),
+			"acecloud_vms":                datasources.DataSourceAceCloudVMs(),
+			"acecloud_caller_identity":    datasources.DataSourceAceCloudCallerIdentity(),
 		},
-		ConfigureContextFunc: configureProvider, 
 	}
-}
\ No newline at end of file
+	p.ConfigureContextFunc = func(ctx context.Context, d *schema.ResourceData) (any, diag.Diagnostics) {
+		return configureProvider(ctx, d, userAgent(p, version))
+	}
+	return p
+}
+
+// userAgent identifies the provider's requests to the API, e.g.
+// "Terraform/1.9.5 (+https://www.terraform.io) Terraform-Plugin-SDK/2.38.1
+// terraform-provider-acecloud/1.2.0 (go1.25.0; linux/amd64)". The Terraform
+// version is only known once Terraform has asked the provider to configure.
+func userAgent(p *schema.Provider, version string) string {
+	return p.UserAgent("terraform-provider-acecloud", fmt.Sprintf("%s (%s; %s/%s)", version, runtime.Version(), runtime.GOOS, runtime.GOARCH))
+}
+
+// endpointsSchema has one optional URL attribute per client service.
+func endpointsSchema() map[string]*schema.Schema {
+	s := make(map[string]*schema.Schema, len(client.Services))
+	for _, svc := range client.Services {
+		s[svc.Key] = &schema.Schema{
+			Type:         schema.TypeString,
+			Optional:     true,
+			Description:  endpointDescription(svc),
+			ValidateFunc: validateEndpointURL,
+		}
+	}
+	return s
+}
+
+// validatePositiveDuration checks that a string parses as a positive Go
+// duration.
+func validatePositiveDuration(i interface{}, k string) ([]string, []error) {
+	v, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return nil, []error{fmt.Errorf("%s must be a duration such as \"30s\" or \"2m\": %s", k, err)}
+	}
+	if d <= 0 {
+		return nil, []error{fmt.Errorf("%s must be positive, got %s", k, v)}
+	}
+	return nil, nil
+}
+
+func endpointDescription(svc client.Service) string {
+	return fmt.Sprintf("Endpoint URL of the %s service, replacing api_endpoint and the service's base path.", svc.Name)
+}
@@ -2,16 +2,26 @@ package acecloud
 
 import (
 	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	// "github.com/hashicorp/terraform-plugin-sdk/v2/helper/logging"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/client"
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/config"
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/helpers"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+func configureProvider(ctx context.Context, d *schema.ResourceData, userAgent string) (any, diag.Diagnostics) {
 
-func configureProvider(ctx context.Context, d *schema.ResourceData) (any, diag.Diagnostics) {
-	
-	// terraformVersion := "1.0+" 
+	// terraformVersion := "1.0+"
 	var diags diag.Diagnostics
 
 	// enableLogging := false
@@ -21,14 +31,336 @@ func configureProvider(ctx context.Context, d *schema.ResourceData) (any, diag.D
 	// 	}
 	// }
 
-	apiEndpoint := d.Get("api_endpoint").(string)
-	apiKey := d.Get("api_key").(string)
-	region := d.Get("region").(string)
-	projectID := d.Get("project_id").(string)
+	// Settings are resolved in this order, first match wins:
+	//  1. the provider block
+	//  2. environment variables (ACECLOUD_API_ENDPOINT, ACECLOUD_API_KEY,
+	//     ACECLOUD_REGION, ACECLOUD_PROJECT_ID)
+	//  3. the selected profile in ~/.acecloud/credentials
+	//  4. the selected profile in ~/.acecloud/config
+	//  5. for region only, the account's default region
+	// The profile comes from the profile argument or ACECLOUD_PROFILE, and
+	// falls back to "default".
+	profile, err := config.LoadProfile(d.Get("profile").(string), config.DefaultConfigFile(), config.DefaultCredentialsFile())
+	if err != nil {
+		return nil, diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       "Unable to load profile",
+			Detail:        err.Error(),
+			AttributePath: cty.GetAttrPath("profile"),
+		}}
+	}
+
+	apiEndpoint := firstNonEmpty(d.Get("api_endpoint").(string), profile.APIEndpoint)
+	apiKey := firstNonEmpty(d.Get("api_key").(string), profile.APIKey)
+	region := firstNonEmpty(d.Get("region").(string), profile.Region)
+	projectID := firstNonEmpty(d.Get("project_id").(string), profile.ProjectID)
+
+	diags = append(diags, validateAPIEndpoint(apiEndpoint)...)
+	if apiKey == "" {
+		diags = append(diags, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       "Missing API key",
+			Detail:        "api_key must be set in the provider block, through ACECLOUD_API_KEY or in a shared credentials profile.",
+			AttributePath: cty.GetAttrPath("api_key"),
+		})
+	}
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	httpClient, transportDiags := newHTTPClient(d)
+	diags = append(diags, transportDiags...)
+	if diags.HasError() {
+		return nil, diags
+	}
 
 	c := client.NewAceCloudClient(apiEndpoint, apiKey, region, projectID)
+	c.HTTPClient = httpClient
+	c.UserAgent = userAgent
+	c.PageSize = d.Get("list_page_size").(int)
+	c.Endpoints = expandEndpoints(d.Get("endpoints").([]interface{}))
+	c.ClientID = d.Get("client_id").(int)
+	c.UserID = d.Get("user_id").(int)
+	c.Limiter = client.NewLimiter(d.Get("max_requests_per_second").(float64), d.Get("max_concurrent_requests").(int))
 
+	skipValidation := d.Get("skip_credentials_validation").(bool)
+	if !skipValidation {
+		diags = append(diags, validateCredentials(ctx, c)...)
+		if diags.HasError() {
+			return nil, diags
+		}
+	}
 
+	diags = append(diags, resolveRegion(ctx, c, skipValidation)...)
+	if diags.HasError() {
+		return nil, diags
+	}
 
 	return c, diags
-}
\ No newline at end of file
+}
+
+// validateAPIEndpoint checks that the endpoint is an absolute https URL.
+func validateAPIEndpoint(endpoint string) diag.Diagnostics {
+	path := cty.GetAttrPath("api_endpoint")
+	if endpoint == "" {
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       "Missing API endpoint",
+			Detail:        "api_endpoint must be set in the provider block, through ACECLOUD_API_ENDPOINT or in a shared config profile.",
+			AttributePath: path,
+		}}
+	}
+
+	if err := checkEndpointURL(endpoint); err != nil {
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       "Invalid API endpoint",
+			Detail:        fmt.Sprintf("api_endpoint %s.", err),
+			AttributePath: path,
+		}}
+	}
+	return nil
+}
+
+// checkEndpointURL is the rule shared by api_endpoint and the endpoints
+// overrides: the API key is sent with every request, so endpoints must be
+// absolute https URLs. http is allowed for loopback hosts, where the key
+// never leaves the machine, so that a local mock server can be used.
+func checkEndpointURL(endpoint string) error {
+	u, err := url.Parse(endpoint)
+	switch {
+	case err != nil:
+		return fmt.Errorf("%q is not a valid URL: %s", endpoint, err)
+	case u.Scheme == "http" && isLoopbackHost(u.Hostname()):
+	case u.Scheme != "https":
+		return fmt.Errorf("%q must use the https scheme", endpoint)
+	case u.Host == "":
+		return fmt.Errorf("%q has no host", endpoint)
+	}
+	return nil
+}
+
+// isLoopbackHost reports whether host is localhost or a loopback address.
+func isLoopbackHost(host string) bool {
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// validateEndpointURL applies checkEndpointURL to the endpoints overrides.
+func validateEndpointURL(i interface{}, k string) ([]string, []error) {
+	v, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+	if err := checkEndpointURL(v); err != nil {
+		return nil, []error{fmt.Errorf("%s %s", k, err)}
+	}
+	return nil, nil
+}
+
+// newHTTPClient builds the HTTP client from the TLS, proxy and timeout
+// settings. Certificates and keys are read from files here, so that a missing
+// file is reported against the argument naming it.
+func newHTTPClient(d *schema.ResourceData) (*http.Client, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	cfg := client.TransportConfig{
+		Insecure: d.Get("insecure").(bool),
+		ProxyURL: d.Get("proxy_url").(string),
+	}
+
+	// The schema validates the duration.
+	cfg.Timeout, _ = time.ParseDuration(d.Get("request_timeout").(string))
+
+	if path := d.Get("ca_cert_file").(string); path != "" {
+		pem, err := os.ReadFile(path)
+		if err != nil {
+			return nil, diag.Diagnostics{{
+				Severity:      diag.Error,
+				Summary:       "Unable to read CA certificate",
+				Detail:        err.Error(),
+				AttributePath: cty.GetAttrPath("ca_cert_file"),
+			}}
+		}
+		cfg.CACertPEM = pem
+	} else if pem := d.Get("ca_cert_pem").(string); pem != "" {
+		cfg.CACertPEM = []byte(pem)
+	}
+
+	for _, arg := range []struct {
+		name string
+		pem  *[]byte
+	}{
+		{"client_cert", &cfg.ClientCertPEM},
+		{"client_key", &cfg.ClientKeyPEM},
+	} {
+		pem, err := pemOrFile(d.Get(arg.name).(string))
+		if err != nil {
+			return nil, diag.Diagnostics{{
+				Severity:      diag.Error,
+				Summary:       fmt.Sprintf("Unable to read %s", arg.name),
+				Detail:        err.Error(),
+				AttributePath: cty.GetAttrPath(arg.name),
+			}}
+		}
+		*arg.pem = pem
+	}
+
+	if cfg.Insecure {
+		diags = append(diags, diag.Diagnostic{
+			Severity:      diag.Warning,
+			Summary:       "TLS certificate verification is disabled",
+			Detail:        "insecure is set, so the provider does not verify the API endpoint's certificate and cannot detect an attacker intercepting its requests, including the API key. Configure ca_cert_file or ca_cert_pem instead to trust a private CA.",
+			AttributePath: cty.GetAttrPath("insecure"),
+		})
+	}
+
+	httpClient, err := client.NewHTTPClient(cfg)
+	if err != nil {
+		return nil, append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Invalid TLS or proxy settings",
+			Detail:   err.Error(),
+		})
+	}
+	return httpClient, diags
+}
+
+// pemOrFile returns v if it holds PEM data, and otherwise reads the file it
+// names. An empty v returns nil.
+func pemOrFile(v string) ([]byte, error) {
+	if v == "" || strings.Contains(v, "-----BEGIN") {
+		return []byte(v), nil
+	}
+	return os.ReadFile(v)
+}
+
+// validateCredentials makes one authenticated call so a wrong api_key or
+// api_endpoint fails at configure time instead of on the first resource
+// operation. It also checks client_id and user_id against the key's identity.
+func validateCredentials(ctx context.Context, c *client.AceCloudClient) diag.Diagnostics {
+	id, err := c.GetCallerIdentity(ctx)
+	if err != nil {
+		if client.IsAuthError(err) {
+			return diag.Diagnostics{{
+				Severity:      diag.Error,
+				Summary:       "Invalid API key",
+				Detail:        fmt.Sprintf("AceCloud rejected the configured api_key: %s", err),
+				AttributePath: cty.GetAttrPath("api_key"),
+			}}
+		}
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       "Unable to reach the AceCloud API",
+			Detail:        fmt.Sprintf("Validating credentials against %s failed: %s\n\nCheck api_endpoint, or set skip_credentials_validation to skip this check.", c.BaseURL, err),
+			AttributePath: cty.GetAttrPath("api_endpoint"),
+		}}
+	}
+
+	var diags diag.Diagnostics
+	if c.ClientID != 0 && id.ClientID != c.ClientID {
+		diags = append(diags, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       "client_id does not match the API key",
+			Detail:        fmt.Sprintf("The API key belongs to client %d, but client_id is set to %d.", id.ClientID, c.ClientID),
+			AttributePath: cty.GetAttrPath("client_id"),
+		})
+	}
+	if c.UserID != 0 && id.UserID != c.UserID {
+		diags = append(diags, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       "user_id does not match the API key",
+			Detail:        fmt.Sprintf("The API key belongs to user %d, but user_id is set to %d.", id.UserID, c.UserID),
+			AttributePath: cty.GetAttrPath("user_id"),
+		})
+	}
+	return diags
+}
+
+// resolveRegion defaults c.Region to the account's default region and checks
+// a configured region against the regions available to the account. With
+// validation skipped no call is made, so the region must be configured.
+func resolveRegion(ctx context.Context, c *client.AceCloudClient, skipValidation bool) diag.Diagnostics {
+	path := cty.GetAttrPath("region")
+	if skipValidation {
+		if c.Region == "" {
+			return diag.Diagnostics{{
+				Severity:      diag.Error,
+				Summary:       "Missing region",
+				Detail:        "region must be set when skip_credentials_validation is enabled, because the default region cannot be looked up.",
+				AttributePath: path,
+			}}
+		}
+		return nil
+	}
+
+	regions, err := c.ListRegions(ctx, nil)
+	if err != nil {
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       "Unable to list regions",
+			Detail:        err.Error(),
+			AttributePath: path,
+		}}
+	}
+
+	names := make([]string, 0, len(regions))
+	for _, r := range regions {
+		names = append(names, r.Name)
+	}
+
+	if c.Region != "" {
+		if !helpers.StringInSlice(c.Region, names) {
+			return diag.Diagnostics{{
+				Severity:      diag.Error,
+				Summary:       "Unknown region",
+				Detail:        fmt.Sprintf("Region %q is not available to this account. Available regions: %s.", c.Region, strings.Join(names, ", ")),
+				AttributePath: path,
+			}}
+		}
+		return nil
+	}
+
+	for _, r := range regions {
+		if r.IsDefault {
+			c.Region = r.Name
+			return nil
+		}
+	}
+	if len(regions) == 1 {
+		c.Region = regions[0].Name
+		return nil
+	}
+
+	return diag.Diagnostics{{
+		Severity:      diag.Error,
+		Summary:       "Missing region",
+		Detail:        fmt.Sprintf("The account has no default region. Set region or ACECLOUD_REGION to one of: %s.", strings.Join(names, ", ")),
+		AttributePath: path,
+	}}
+}
+
+func expandEndpoints(l []interface{}) map[string]string {
+	endpoints := map[string]string{}
+	if len(l) == 0 || l[0] == nil {
+		return endpoints
+	}
+	m := l[0].(map[string]interface{})
+	for _, svc := range client.Services {
+		if v, ok := m[svc.Key].(string); ok && v != "" {
+			endpoints[svc.Key] = v
+		}
+	}
+	return endpoints
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
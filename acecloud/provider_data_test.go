@@ -0,0 +1,390 @@
+package acecloud
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"maps"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/client"
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/client/types"
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/fakeapi"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// stubClient returns a client whose every request is answered with status
+// and body.
+func stubClient(status int, body string) *client.AceCloudClient {
+	c := client.NewAceCloudClient("https://api.acecloud.test", "test-api-key", "test-region", "test-project")
+	c.HTTPClient = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: status,
+			Header:     http.Header{"Content-Type": {"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    req,
+		}, nil
+	})}
+	return c
+}
+
+// checkDiags compares diags with the expected error summaries, and the
+// detail and attribute of the first one.
+func checkDiags(t *testing.T, diags diag.Diagnostics, summaries []string, detail, attr string) {
+	t.Helper()
+	if len(diags) != len(summaries) {
+		t.Fatalf("got %d diagnostics, want %d: %v", len(diags), len(summaries), diags)
+	}
+	for i, d := range diags {
+		if d.Severity != diag.Error || d.Summary != summaries[i] {
+			t.Errorf("diagnostic %d = %q, want error %q", i, d.Summary, summaries[i])
+		}
+	}
+	if len(diags) == 0 {
+		return
+	}
+	if !strings.Contains(diags[0].Detail, detail) {
+		t.Errorf("detail = %q, want it to contain %q", diags[0].Detail, detail)
+	}
+	if attr == "" {
+		if len(diags[0].AttributePath) != 0 {
+			t.Errorf("attribute path = %#v, want none", diags[0].AttributePath)
+		}
+	} else if !diags[0].AttributePath.Equals(cty.GetAttrPath(attr)) {
+		t.Errorf("attribute path = %#v, want %s", diags[0].AttributePath, attr)
+	}
+}
+
+func TestExpandEndpoints(t *testing.T) {
+	got := expandEndpoints([]interface{}{map[string]interface{}{
+		"dns":      "https://dns.internal.test",
+		"database": "",
+	}})
+	if want := map[string]string{"dns": "https://dns.internal.test"}; !maps.Equal(got, want) {
+		t.Errorf("expandEndpoints = %v, want %v", got, want)
+	}
+	if got := expandEndpoints(nil); len(got) != 0 {
+		t.Errorf("expandEndpoints(nil) = %v", got)
+	}
+}
+
+func TestValidateAPIEndpoint(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		endpoint string
+		summary  string
+		detail   string
+	}{
+		{"valid", "https://api.acecloud.ai", "", ""},
+		{"valid with path", "https://acecloud.internal.example.com:8443/api", "", ""},
+		{"missing", "", "Missing API endpoint", "api_endpoint must be set"},
+		{"unparsable", "https://api.acecloud.ai:port", "Invalid API endpoint", "is not a valid URL"},
+		{"http", "http://api.acecloud.ai", "Invalid API endpoint", `"http://api.acecloud.ai" must use the https scheme`},
+		{"http localhost", "http://localhost:3001", "", ""},
+		{"http loopback", "http://127.0.0.1:3001", "", ""},
+		{"http loopback IPv6", "http://[::1]:3001", "", ""},
+		{"http localhost subdomain", "http://localhost.example.com", "Invalid API endpoint", "must use the https scheme"},
+		{"relative", "api.acecloud.ai", "Invalid API endpoint", "must use the https scheme"},
+		{"no host", "https://", "Invalid API endpoint", `"https://" has no host`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var summaries []string
+			if tc.summary != "" {
+				summaries = []string{tc.summary}
+			}
+			checkDiags(t, validateAPIEndpoint(tc.endpoint), summaries, tc.detail, "api_endpoint")
+		})
+	}
+}
+
+// The endpoints overrides follow the same rule as api_endpoint, so that it
+// cannot be bypassed by overriding every service.
+func TestEndpointsSchemaValidation(t *testing.T) {
+	for key, s := range endpointsSchema() {
+		for value, wantErr := range map[string]bool{
+			"https://compute.acecloud.internal": false,
+			"http://compute.acecloud.internal":  true,
+			"http://localhost:3001":             false,
+			"https://":                          true,
+			"compute.acecloud.internal":         true,
+		} {
+			_, errs := s.ValidateFunc(value, key)
+			if (len(errs) > 0) != wantErr {
+				t.Errorf("endpoints.%s = %q: errors %v, want error %t", key, value, errs, wantErr)
+			}
+		}
+	}
+}
+
+func TestValidateCredentials(t *testing.T) {
+	const identity = `{"data":{"account_id":"acc-1","client_id":42,"user_id":7}}`
+	for _, tc := range []struct {
+		name             string
+		status           int
+		body             string
+		clientID, userID int
+		summaries        []string
+		detail, attr     string
+	}{
+		{name: "valid", status: http.StatusOK, body: identity},
+		{name: "client and user match", status: http.StatusOK, body: identity, clientID: 42, userID: 7},
+		{
+			name: "unauthorized", status: http.StatusUnauthorized, body: `{"error":true,"message":"invalid API key"}`,
+			summaries: []string{"Invalid API key"},
+			detail:    "API error 401: invalid API key", attr: "api_key",
+		},
+		{
+			name: "forbidden", status: http.StatusForbidden, body: `{"error":true,"message":"API key is disabled"}`,
+			summaries: []string{"Invalid API key"},
+			detail:    "API error 403: API key is disabled", attr: "api_key",
+		},
+		{
+			name: "server error", status: http.StatusBadGateway, body: "bad gateway",
+			summaries: []string{"Unable to reach the AceCloud API"},
+			detail:    "Validating credentials against https://api.acecloud.test failed: ", attr: "api_endpoint",
+		},
+		{
+			name: "client_id mismatch", status: http.StatusOK, body: identity, clientID: 43, userID: 7,
+			summaries: []string{"client_id does not match the API key"},
+			detail:    "belongs to client 42, but client_id is set to 43", attr: "client_id",
+		},
+		{
+			name: "user_id mismatch", status: http.StatusOK, body: identity, clientID: 42, userID: 2,
+			summaries: []string{"user_id does not match the API key"},
+			detail:    "belongs to user 7, but user_id is set to 2", attr: "user_id",
+		},
+		{
+			name: "both mismatch", status: http.StatusOK, body: identity, clientID: 1, userID: 2,
+			summaries: []string{"client_id does not match the API key", "user_id does not match the API key"},
+			detail:    "client_id is set to 1", attr: "client_id",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			c := stubClient(tc.status, tc.body)
+			c.ClientID, c.UserID = tc.clientID, tc.userID
+			checkDiags(t, validateCredentials(context.Background(), c), tc.summaries, tc.detail, tc.attr)
+		})
+	}
+}
+
+func TestConfigurePrecedence(t *testing.T) {
+	dir := t.TempDir()
+	configFile := filepath.Join(dir, "config")
+	credentialsFile := filepath.Join(dir, "credentials")
+	for path, content := range map[string]string{
+		configFile: `
+[default]
+api_endpoint = https://config.acecloud.test
+region       = config-region
+project_id   = config-project
+
+[profile staging]
+api_endpoint = https://staging.acecloud.test
+region       = staging-region
+`,
+		credentialsFile: `
+[default]
+api_key = credentials-key
+region  = credentials-region
+
+[staging]
+api_key = staging-key
+`,
+	} {
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	type settings struct{ endpoint, key, region, project string }
+	for _, tc := range []struct {
+		name   string
+		config map[string]interface{}
+		env    map[string]string
+		want   settings
+	}{
+		{
+			name: "profile files",
+			// The credentials file wins over the config file.
+			want: settings{"https://config.acecloud.test", "credentials-key", "credentials-region", "config-project"},
+		},
+		{
+			name: "environment over files",
+			env:  map[string]string{"ACECLOUD_API_KEY": "env-key", "ACECLOUD_REGION": "env-region"},
+			want: settings{"https://config.acecloud.test", "env-key", "env-region", "config-project"},
+		},
+		{
+			name:   "provider block over environment",
+			config: map[string]interface{}{"api_key": "block-key", "region": "block-region", "api_endpoint": "https://block.acecloud.test"},
+			env:    map[string]string{"ACECLOUD_API_KEY": "env-key", "ACECLOUD_REGION": "env-region", "ACECLOUD_API_ENDPOINT": "https://env.acecloud.test"},
+			want:   settings{"https://block.acecloud.test", "block-key", "block-region", "config-project"},
+		},
+		{
+			name: "ACECLOUD_PROFILE",
+			env:  map[string]string{"ACECLOUD_PROFILE": "staging"},
+			want: settings{"https://staging.acecloud.test", "staging-key", "staging-region", ""},
+		},
+		{
+			name:   "profile argument over ACECLOUD_PROFILE",
+			config: map[string]interface{}{"profile": "staging"},
+			env:    map[string]string{"ACECLOUD_PROFILE": "undefined"},
+			want:   settings{"https://staging.acecloud.test", "staging-key", "staging-region", ""},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, name := range []string{"ACECLOUD_API_ENDPOINT", "ACECLOUD_API_KEY", "ACECLOUD_PROFILE", "ACECLOUD_REGION", "ACECLOUD_PROJECT_ID"} {
+				t.Setenv(name, tc.env[name])
+			}
+			t.Setenv("ACECLOUD_CONFIG_FILE", configFile)
+			t.Setenv("ACECLOUD_SHARED_CREDENTIALS_FILE", credentialsFile)
+
+			raw := map[string]interface{}{"skip_credentials_validation": true}
+			for k, v := range tc.config {
+				raw[k] = v
+			}
+			d := schema.TestResourceDataRaw(t, Provider("test").Schema, raw)
+
+			meta, diags := configureProvider(context.Background(), d, "test")
+			if diags.HasError() {
+				t.Fatalf("configureProvider: %v", diags)
+			}
+			c := meta.(*client.AceCloudClient)
+			if got := (settings{c.BaseURL, c.APIKey, c.Region, c.ProjectID}); got != tc.want {
+				t.Errorf("configured %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+
+	t.Run("undefined profile", func(t *testing.T) {
+		t.Setenv("ACECLOUD_PROFILE", "production")
+		t.Setenv("ACECLOUD_CONFIG_FILE", configFile)
+		t.Setenv("ACECLOUD_SHARED_CREDENTIALS_FILE", credentialsFile)
+		d := schema.TestResourceDataRaw(t, Provider("test").Schema, map[string]interface{}{})
+
+		_, diags := configureProvider(context.Background(), d, "test")
+		checkDiags(t, diags, []string{"Unable to load profile"}, `profile not found: "production" is not defined`, "profile")
+	})
+}
+
+func TestResolveRegion(t *testing.T) {
+	ctx := context.Background()
+	mumbai := types.Region{Name: "ap-south-mum-1", Status: "active"}
+	delhi := types.Region{Name: "ap-south-del-1", Status: "active"}
+	defaultDelhi := delhi
+	defaultDelhi.IsDefault = true
+
+	for _, tc := range []struct {
+		name       string
+		regions    []types.Region
+		configured string
+		want       string
+		summary    string
+		detail     string
+	}{
+		{name: "configured", regions: []types.Region{mumbai, delhi}, configured: "ap-south-del-1", want: "ap-south-del-1"},
+		{name: "default region", regions: []types.Region{mumbai, defaultDelhi}, want: "ap-south-del-1"},
+		{name: "configured over default", regions: []types.Region{mumbai, defaultDelhi}, configured: "ap-south-mum-1", want: "ap-south-mum-1"},
+		{name: "single region", regions: []types.Region{mumbai}, want: "ap-south-mum-1"},
+		{
+			name:    "no default region",
+			regions: []types.Region{mumbai, delhi},
+			summary: "Missing region",
+			detail:  "The account has no default region. Set region or ACECLOUD_REGION to one of: ap-south-mum-1, ap-south-del-1.",
+		},
+		{
+			name:       "unknown region",
+			regions:    []types.Region{mumbai, defaultDelhi},
+			configured: "us-east-1",
+			summary:    "Unknown region",
+			detail:     `Region "us-east-1" is not available to this account. Available regions: ap-south-mum-1, ap-south-del-1.`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			body, err := json.Marshal(map[string]interface{}{"data": tc.regions})
+			if err != nil {
+				t.Fatal(err)
+			}
+			c := stubClient(http.StatusOK, string(body))
+			c.Region = tc.configured
+
+			diags := resolveRegion(ctx, c, false)
+			if tc.summary != "" {
+				checkDiags(t, diags, []string{tc.summary}, tc.detail, "region")
+				return
+			}
+			checkDiags(t, diags, nil, "", "")
+			if c.Region != tc.want {
+				t.Errorf("region = %q, want %q", c.Region, tc.want)
+			}
+		})
+	}
+
+	t.Run("list error", func(t *testing.T) {
+		c := stubClient(http.StatusServiceUnavailable, "unavailable")
+		checkDiags(t, resolveRegion(ctx, c, false), []string{"Unable to list regions"}, "API error 503", "region")
+	})
+
+	// With validation skipped, the configured region is trusted and no
+	// request is made.
+	t.Run("skip validation", func(t *testing.T) {
+		c := stubClient(http.StatusOK, `{"data":[]}`)
+		c.HTTPClient.Transport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			t.Errorf("sent %s %s with validation skipped", req.Method, req.URL.Path)
+			return nil, http.ErrUseLastResponse
+		})
+		c.Region = "us-east-1"
+		checkDiags(t, resolveRegion(ctx, c, true), nil, "", "")
+		if c.Region != "us-east-1" {
+			t.Errorf("region = %q, want the configured us-east-1", c.Region)
+		}
+
+		c.Region = ""
+		checkDiags(t, resolveRegion(ctx, c, true), []string{"Missing region"}, "region must be set when skip_credentials_validation is enabled", "region")
+	})
+}
+
+// Configuring the provider checks the credentials and the region, one
+// request each, over a transport that trusts ca_cert_pem.
+func TestConfigureRequests(t *testing.T) {
+	s := fakeapi.New()
+	t.Cleanup(s.Close)
+	for _, name := range []string{"ACECLOUD_API_ENDPOINT", "ACECLOUD_API_KEY", "ACECLOUD_PROFILE", "ACECLOUD_REGION", "ACECLOUD_PROJECT_ID"} {
+		t.Setenv(name, "")
+	}
+	t.Setenv("ACECLOUD_CONFIG_FILE", filepath.Join(t.TempDir(), "config"))
+	t.Setenv("ACECLOUD_SHARED_CREDENTIALS_FILE", filepath.Join(t.TempDir(), "credentials"))
+
+	d := schema.TestResourceDataRaw(t, Provider("test").Schema, map[string]interface{}{
+		"api_endpoint": s.URL,
+		"api_key":      fakeapi.APIKey,
+		"ca_cert_pem":  string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: s.Certificate().Raw})),
+	})
+	meta, diags := configureProvider(context.Background(), d, "test")
+	if diags.HasError() {
+		t.Fatalf("configureProvider: %v", diags)
+	}
+	if c := meta.(*client.AceCloudClient); c.Region != fakeapi.DefaultRegion {
+		t.Errorf("region = %q, want the default %q", c.Region, fakeapi.DefaultRegion)
+	}
+
+	var paths []string
+	for _, r := range s.Requests() {
+		paths = append(paths, r.Method+" "+r.Path)
+	}
+	if want := []string{"GET /iam/caller-identity", "GET /iam/regions"}; strings.Join(paths, ", ") != strings.Join(want, ", ") {
+		t.Errorf("requests = %v, want %v", paths, want)
+	}
+}
@@ -0,0 +1,118 @@
+package resources
+
+import (
+	"context"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// stubAPI answers a client's requests from handlers keyed by
+// "<method> <path>" and records every request. Requests without a handler
+// get a 404.
+type stubAPI struct {
+	handlers map[string]func(*http.Request) (int, string)
+	requests []*http.Request
+	bodies   []string
+}
+
+func newStubAPI() *stubAPI {
+	return &stubAPI{handlers: map[string]func(*http.Request) (int, string){}}
+}
+
+// handle makes route always answer with status and body.
+func (s *stubAPI) handle(route string, status int, body string) {
+	s.handlers[route] = func(*http.Request) (int, string) { return status, body }
+}
+
+// client returns a client for the stub, scoped to the provider defaults
+// test-region and test-project.
+func (s *stubAPI) client() *client.AceCloudClient {
+	c := client.NewAceCloudClient("https://api.acecloud.test", "test-api-key", "test-region", "test-project")
+	c.HTTPClient = &http.Client{Transport: s}
+	return c
+}
+
+func (s *stubAPI) RoundTrip(req *http.Request) (*http.Response, error) {
+	body := ""
+	if req.Body != nil {
+		b, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		body = string(b)
+	}
+	s.requests = append(s.requests, req)
+	s.bodies = append(s.bodies, body)
+
+	status, respBody := http.StatusNotFound, `{"error":true,"message":"not found"}`
+	if h, ok := s.handlers[req.Method+" "+req.URL.Path]; ok {
+		status, respBody = h(req)
+	}
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(respBody)),
+		Request:    req,
+	}, nil
+}
+
+// routes returns the requests received as "<method> <path> <region>".
+func (s *stubAPI) routes() []string {
+	var routes []string
+	for _, req := range s.requests {
+		routes = append(routes, req.Method+" "+req.URL.Path+" "+req.URL.Query().Get("region"))
+	}
+	return routes
+}
+
+// wantRoutes fails unless api received exactly the requests want, as
+// returned by routes.
+func wantRoutes(t *testing.T, api *stubAPI, want ...string) {
+	t.Helper()
+	if got := api.routes(); !slices.Equal(got, want) {
+		t.Errorf("requests:\n%q\nwant:\n%q", got, want)
+	}
+}
+
+// diffAttributes returns the sorted names of the attributes diff changes.
+func diffAttributes(diff *terraform.InstanceDiff) []string {
+	if diff == nil {
+		return nil
+	}
+	return slices.Sorted(maps.Keys(diff.Attributes))
+}
+
+// resourceData returns data for r holding raw as its configuration and, when
+// id is set, that ID.
+func resourceData(t *testing.T, r *schema.Resource, id string, raw map[string]interface{}) *schema.ResourceData {
+	t.Helper()
+	d := schema.TestResourceDataRaw(t, r.Schema, raw)
+	d.SetId(id)
+	return d
+}
+
+// importState runs r's importer for id and reads each resulting resource, as
+// terraform import does.
+func importState(t *testing.T, r *schema.Resource, id string, c *client.AceCloudClient) (*schema.ResourceData, diag.Diagnostics) {
+	t.Helper()
+	d := r.Data(nil)
+	d.SetId(id)
+	imported, err := r.Importer.StateContext(context.Background(), d, c)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	if len(imported) != 1 {
+		t.Fatalf("import returned %d resources, want 1", len(imported))
+	}
+	d = imported[0]
+	return d, r.ReadContext(context.Background(), d, c)
+}
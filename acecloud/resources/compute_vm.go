@@ -2,12 +2,23 @@ package resources
 
 import (
 	"context"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/client"
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/client/types"
 	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/helpers"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/client/types"
+)
+
+const (
+	vmStatusActive   = "ACTIVE"
+	vmStatusBuild    = "BUILD"
+	vmStatusDeleting = "DELETING"
+	vmStatusError    = "ERROR"
 )
 
 func ResourceAceCloudVM() *schema.Resource {
@@ -18,6 +29,37 @@ func ResourceAceCloudVM() *schema.Resource {
 		UpdateContext: resourceAceCloudVMUpdate,
 		DeleteContext: resourceVMDelete,
 
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceAceCloudVMImport,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+
+		Identity: &schema.ResourceIdentity{
+			SchemaFunc: func() map[string]*schema.Schema {
+				return map[string]*schema.Schema{
+					"id": {
+						Type:              schema.TypeString,
+						RequiredForImport: true,
+						Description:       "ID of the VM instance",
+					},
+					"region": {
+						Type:              schema.TypeString,
+						OptionalForImport: true,
+						Description:       "Region of the VM instance",
+					},
+					"project_id": {
+						Type:              schema.TypeString,
+						OptionalForImport: true,
+						Description:       "Project of the VM instance",
+					},
+				}
+			},
+		},
+
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:        schema.TypeString,
@@ -27,22 +69,26 @@ func ResourceAceCloudVM() *schema.Resource {
 			"flavor": {
 				Type:        schema.TypeString,
 				Required:    true,
+				ForceNew:    true,
 				Description: "Flavor ID for the VM instance",
 			},
 			"boot_uuid": {
 				Type:        schema.TypeString,
 				Required:    true,
+				ForceNew:    true,
 				Description: "Boot image UUID",
 			},
 			"delete_on_termination": {
 				Type:        schema.TypeBool,
 				Optional:    true,
+				ForceNew:    true,
 				Default:     true,
 				Description: "Whether to delete volumes on VM termination",
 			},
 			"network": {
 				Type:        schema.TypeList,
 				Optional:    true,
+				ForceNew:    true,
 				Description: "List of network IDs to attach",
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
@@ -51,6 +97,7 @@ func ResourceAceCloudVM() *schema.Resource {
 			"key": {
 				Type:        schema.TypeString,
 				Required:    true,
+				ForceNew:    true,
 				Description: "SSH key for accessing the VM",
 				// Elem: &schema.Schema{
 				//     Type: schema.TypeString,
@@ -59,6 +106,7 @@ func ResourceAceCloudVM() *schema.Resource {
 			"security_group": {
 				Type:        schema.TypeList,
 				Optional:    true,
+				ForceNew:    true,
 				Description: "List of security group IDs to apply",
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
@@ -67,18 +115,21 @@ func ResourceAceCloudVM() *schema.Resource {
 			"source_type": {
 				Type:        schema.TypeString,
 				Optional:    true,
+				ForceNew:    true,
 				Default:     "image",
 				Description: "Source type for boot device",
 			},
 			"availability_zone": {
 				Type:        schema.TypeString,
 				Optional:    true,
+				ForceNew:    true,
 				Default:     "nova",
 				Description: "Availability zone for the VM",
 			},
 			"billing_type": {
 				Type:        schema.TypeString,
 				Optional:    true,
+				ForceNew:    true,
 				Default:     "hourly",
 				Description: "Billing type for the VM",
 			},
@@ -86,6 +137,7 @@ func ResourceAceCloudVM() *schema.Resource {
 			"volumes": {
 				Type:        schema.TypeList,
 				Optional:    true,
+				ForceNew:    true,
 				Description: "List of volumes to attach",
 
 				Elem: &schema.Resource{
@@ -93,22 +145,26 @@ func ResourceAceCloudVM() *schema.Resource {
 						"boot": {
 							Type:        schema.TypeBool,
 							Optional:    true,
+							ForceNew:    true,
 							Default:     false,
 							Description: "Whether this is the boot volume",
 						},
 						"volume_type": {
 							Type:        schema.TypeString,
 							Required:    true,
+							ForceNew:    true,
 							Description: "Type of the volume",
 						},
 						"size": {
 							Type:        schema.TypeInt,
 							Required:    true,
+							ForceNew:    true,
 							Description: "Size of the volume in GB",
 						},
 						"billing_type": {
 							Type:        schema.TypeString,
 							Optional:    true,
+							ForceNew:    true,
 							Default:     "hourly",
 							Description: "Billing type for the volume",
 						},
@@ -118,6 +174,7 @@ func ResourceAceCloudVM() *schema.Resource {
 			"vm_count": {
 				Type:        schema.TypeInt,
 				Optional:    true,
+				ForceNew:    true,
 				Default:     1,
 				Description: "Number of VM instances to create",
 			},
@@ -136,12 +193,14 @@ func ResourceAceCloudVM() *schema.Resource {
 				Computed:    true,
 				Description: "IP address of the VM instance",
 			},
+			"region":     helpers.RegionSchema(),
+			"project_id": helpers.ProjectIDSchema(),
 		},
 	}
 }
 
 func resourceAceCloudVMCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	c := meta.(*client.AceCloudClient)
+	c := helpers.ScopedClient(d, meta)
 
 	req := &types.VMCreateRequest{
 		Name:                d.Get("name").(string),
@@ -192,17 +251,19 @@ func resourceAceCloudVMCreate(ctx context.Context, d *schema.ResourceData, meta
 		return diag.FromErr(err)
 	}
 
-	id := resp.Data.ID
+	id := resp.ID
 	d.SetId(id)
 	_ = d.Set("instance_id", id)
-	// Backend response doesn’t include status/ip yet; leave unset.
 
-	// No read for now.
-	return nil
+	if err := WaitForVM(ctx, c, id, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return diag.Errorf("error waiting for VM (%s) to be created: %s", id, err)
+	}
+
+	return resourceAceCloudVMRead(ctx, d, meta)
 }
 
 func resourceAceCloudVMRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	c := meta.(*client.AceCloudClient)
+	c := helpers.ScopedClient(d, meta)
 
 	id := d.Id()
 	resp, err := c.GetVM(ctx, id)
@@ -215,30 +276,79 @@ func resourceAceCloudVMRead(ctx context.Context, d *schema.ResourceData, meta in
 		return diag.FromErr(err)
 	}
 
-
-	_ = d.Set("instance_id", resp.Data.ID)
-	_ = d.Set("status", resp.Data.Status)
+	// The API does not return the boot image, networks, security groups,
+	// volumes or the other create options, so they are kept as configured.
+	_ = d.Set("name", resp.Name)
+	_ = d.Set("flavor", resp.Flavor)
+	_ = d.Set("key", resp.Key)
+	_ = d.Set("availability_zone", resp.AvailabilityZone)
+	_ = d.Set("instance_id", resp.ID)
+	_ = d.Set("status", resp.Status)
 
 	// Set IP address if available (first public address)
-	if len(resp.Data.Addresses.Public) > 0 {
-		_ = d.Set("ip_address", resp.Data.Addresses.Public[0].Addr)
-	} else {
-		_ = d.Set("ip_address", "")
+	_ = d.Set("ip_address", resp.PublicIP())
+
+	helpers.SetScope(d, c)
+
+	identity, err := d.Identity()
+	if err != nil {
+		return diag.FromErr(err)
 	}
+	_ = identity.Set("id", resp.ID)
+	_ = identity.Set("region", c.Region)
+	_ = identity.Set("project_id", c.ProjectID)
 
 	return nil
 }
 
+// resourceAceCloudVMImport accepts a bare instance ID, an ID qualified as
+// region/project_id/instance_id, or a resource identity. The create options
+// the API does not return are assumed to have their defaults.
+func resourceAceCloudVMImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	setVMImportDefaults(d)
+
+	if d.Id() == "" {
+		identity, err := d.Identity()
+		if err != nil {
+			return nil, err
+		}
+		d.SetId(identity.Get("id").(string))
+		_ = d.Set("region", identity.Get("region").(string))
+		_ = d.Set("project_id", identity.Get("project_id").(string))
+		return []*schema.ResourceData{d}, nil
+	}
+
+	if !strings.Contains(d.Id(), "/") {
+		return []*schema.ResourceData{d}, nil
+	}
+
+	parts, err := helpers.ParseCompositeID(d.Id(), 3)
+	if err != nil {
+		return nil, err
+	}
+	d.SetId(parts[2])
+	_ = d.Set("region", parts[0])
+	_ = d.Set("project_id", parts[1])
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func setVMImportDefaults(d *schema.ResourceData) {
+	_ = d.Set("delete_on_termination", true)
+	_ = d.Set("source_type", "image")
+	_ = d.Set("billing_type", "hourly")
+	_ = d.Set("vm_count", 1)
+}
+
 func resourceVMDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	c := meta.(*client.AceCloudClient)
+	c := helpers.ScopedClient(d, meta)
 
 	id := d.Id()
 	if id == "" {
 		return nil
 	}
 
-
-	_, err := c.DeleteVMs(ctx, []string{id})
+	err := c.DeleteVMs(ctx, []string{id})
 	if err != nil {
 		if helpers.IsNotFoundError(err) {
 			d.SetId("")
@@ -247,11 +357,59 @@ func resourceVMDelete(ctx context.Context, d *schema.ResourceData, meta interfac
 		return diag.FromErr(err)
 	}
 
+	if err := WaitForVMDeleted(ctx, c, id, d.Timeout(schema.TimeoutDelete)); err != nil {
+		return diag.Errorf("error waiting for VM (%s) to be deleted: %s", id, err)
+	}
+
 	d.SetId("")
 	return nil
 }
+
+// WaitForVM blocks until the VM leaves the build status and is active. The
+// framework implementation of acecloud_vm shares it.
+func WaitForVM(ctx context.Context, c *client.AceCloudClient, id string, timeout time.Duration) error {
+	stateConf := &retry.StateChangeConf{
+		Pending:    []string{vmStatusBuild},
+		Target:     []string{vmStatusActive},
+		Refresh:    vmStatusRefreshFunc(ctx, c, id),
+		Timeout:    timeout,
+		MinTimeout: 5 * time.Second,
+	}
+	_, err := stateConf.WaitForStateContext(ctx)
+	return err
+}
+
+// WaitForVMDeleted blocks until the VM no longer exists.
+func WaitForVMDeleted(ctx context.Context, c *client.AceCloudClient, id string, timeout time.Duration) error {
+	stateConf := &retry.StateChangeConf{
+		Pending:    []string{vmStatusActive, vmStatusBuild, vmStatusDeleting},
+		Target:     []string{},
+		Refresh:    vmStatusRefreshFunc(ctx, c, id),
+		Timeout:    timeout,
+		MinTimeout: 5 * time.Second,
+	}
+	_, err := stateConf.WaitForStateContext(ctx)
+	return err
+}
+
+func vmStatusRefreshFunc(ctx context.Context, c *client.AceCloudClient, id string) retry.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		resp, err := c.GetVM(ctx, id)
+		if err != nil {
+			if helpers.IsNotFoundError(err) {
+				return nil, "", nil
+			}
+			return nil, "", err
+		}
+		if resp.Status == vmStatusError {
+			return resp, resp.Status, fmt.Errorf("VM entered error status")
+		}
+		return resp, resp.Status, nil
+	}
+}
+
 func resourceAceCloudVMUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	c := meta.(*client.AceCloudClient)
+	c := helpers.ScopedClient(d, meta)
 
 	id := d.Id()
 	if id == "" {
@@ -274,6 +432,5 @@ func resourceAceCloudVMUpdate(ctx context.Context, d *schema.ResourceData, meta
 		}
 	}
 
-
 	return resourceAceCloudVMRead(ctx, d, meta)
 }
@@ -0,0 +1,83 @@
+package resources
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestVMImportID(t *testing.T) {
+	for _, tc := range []struct {
+		id                              string
+		wantID, wantRegion, wantProject string
+		wantErr                         bool
+	}{
+		{id: "4f1c2a", wantID: "4f1c2a"},
+		{id: "ap-south-mum-1/1234/4f1c2a", wantID: "4f1c2a", wantRegion: "ap-south-mum-1", wantProject: "1234"},
+		{id: "ap-south-mum-1/4f1c2a", wantErr: true},
+		{id: "ap-south-mum-1//4f1c2a", wantErr: true},
+		// Instance IDs never contain a slash, so a fourth part is a typo
+		// rather than part of the instance ID.
+		{id: "ap-south-mum-1/1234/4f1c2a/extra", wantErr: true},
+	} {
+		d := schema.TestResourceDataRaw(t, ResourceAceCloudVM().Schema, map[string]interface{}{})
+		d.SetId(tc.id)
+
+		got, err := resourceAceCloudVMImport(context.Background(), d, nil)
+		if tc.wantErr {
+			if err == nil {
+				t.Errorf("import of %q succeeded with ID %q", tc.id, d.Id())
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("import of %q: %s", tc.id, err)
+			continue
+		}
+		if len(got) != 1 || d.Id() != tc.wantID || d.Get("region") != tc.wantRegion || d.Get("project_id") != tc.wantProject {
+			t.Errorf("import of %q = ID %q, region %q, project_id %q", tc.id, d.Id(), d.Get("region"), d.Get("project_id"))
+		}
+	}
+}
+
+// An imported VM has the arguments the API returns and the defaults of the
+// other create options, and only a name change is applied in place.
+func TestVMImport(t *testing.T) {
+	api := newStubAPI()
+	api.handle("GET /cloud/instances/vm-1", http.StatusOK, `{"data":{"id":"vm-1","name":"web","status":"ACTIVE","flavor":"C.2x4","key":"deploy","availability_zone":"nova"}}`)
+	r := ResourceAceCloudVM()
+
+	d, diags := importState(t, r, "vm-1", api.client())
+	if diags.HasError() {
+		t.Fatalf("import: %v", diags)
+	}
+	if d.Get("flavor") != "C.2x4" || d.Get("key") != "deploy" || d.Get("availability_zone") != "nova" || d.Get("delete_on_termination") != true || d.Get("vm_count") != 1 {
+		t.Errorf("imported flavor %q, key %q, availability_zone %q, delete_on_termination %v, vm_count %v", d.Get("flavor"), d.Get("key"), d.Get("availability_zone"), d.Get("delete_on_termination"), d.Get("vm_count"))
+	}
+
+	for _, tc := range []struct {
+		key, value  string
+		wantReplace bool
+	}{
+		{"name", "web-renamed", false},
+		{"flavor", "C.4x8", true},
+		{"key", "other", true},
+		{"availability_zone", "nova-2", true},
+	} {
+		config := map[string]interface{}{"name": "web", "flavor": "C.2x4", "boot_uuid": "image-1", "key": "deploy"}
+		config[tc.key] = tc.value
+		// boot_uuid is not returned, so the state is given the configured one.
+		state := d.State()
+		state.Attributes["boot_uuid"] = "image-1"
+		diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), api.client())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff.Attributes[tc.key] == nil || diff.RequiresNew() != tc.wantReplace || !tc.wantReplace && len(diff.Attributes) != 1 {
+			t.Errorf("%s changed: diff %v, replace %t; want replace %t", tc.key, diffAttributes(diff), diff.RequiresNew(), tc.wantReplace)
+		}
+	}
+}
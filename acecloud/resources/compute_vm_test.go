@@ -0,0 +1,342 @@
+package resources_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/acctest"
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/client"
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/client/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// vmImportIgnore lists the arguments the API does not return, which an
+// import therefore cannot restore.
+var vmImportIgnore = []string{
+	"boot_uuid",
+	"network",
+	"security_group",
+	"volumes",
+}
+
+func TestAccVM_basic(t *testing.T) {
+	env := acctest.New(t)
+	name := acctest.RandomName()
+	resourceName := "acecloud_vm.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { env.PreCheck(t) },
+		ProtoV6ProviderFactories: env.ProtoV6ProviderFactories(),
+		CheckDestroy:             testAccCheckVMDestroy(env),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVMConfig(env, name, ""),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVMExists(env, resourceName, nil),
+					resource.TestCheckResourceAttr(resourceName, "name", name),
+					resource.TestCheckResourceAttr(resourceName, "status", "ACTIVE"),
+					resource.TestCheckResourceAttrPair(resourceName, "instance_id", resourceName, "id"),
+					resource.TestCheckResourceAttrSet(resourceName, "ip_address"),
+					resource.TestCheckResourceAttrSet(resourceName, "region"),
+					resource.TestCheckResourceAttrSet(resourceName, "project_id"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: vmImportIgnore,
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateIdFunc:       testAccVMQualifiedImportID(resourceName),
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: vmImportIgnore,
+			},
+		},
+	})
+}
+
+func TestAccVM_updateName(t *testing.T) {
+	env := acctest.New(t)
+	name := acctest.RandomName()
+	renamed := name + "-renamed"
+	resourceName := "acecloud_vm.test"
+	var before, after types.VM
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { env.PreCheck(t) },
+		ProtoV6ProviderFactories: env.ProtoV6ProviderFactories(),
+		CheckDestroy:             testAccCheckVMDestroy(env),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVMConfig(env, name, ""),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVMExists(env, resourceName, &before),
+					resource.TestCheckResourceAttr(resourceName, "name", name),
+				),
+			},
+			{
+				Config: testAccVMConfig(env, renamed, ""),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVMExists(env, resourceName, &after),
+					resource.TestCheckResourceAttr(resourceName, "name", renamed),
+					func(*terraform.State) error {
+						if before.ID != after.ID {
+							return fmt.Errorf("VM was replaced (%s -> %s), want an in-place rename", before.ID, after.ID)
+						}
+						if after.Name != renamed {
+							return fmt.Errorf("API name = %q, want %q", after.Name, renamed)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+// TestAccVM_drift renames the VM behind Terraform's back and expects the next
+// plan to rename it back.
+func TestAccVM_drift(t *testing.T) {
+	env := acctest.New(t)
+	name := acctest.RandomName()
+	resourceName := "acecloud_vm.test"
+	var vm types.VM
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { env.PreCheck(t) },
+		ProtoV6ProviderFactories: env.ProtoV6ProviderFactories(),
+		CheckDestroy:             testAccCheckVMDestroy(env),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVMConfig(env, name, ""),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVMExists(env, resourceName, &vm),
+					func(*terraform.State) error {
+						_, err := env.Client().UpdateVM(context.Background(), vm.ID, &types.VMUpdateRequest{Name: name + "-drifted"})
+						return err
+					},
+				),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccVMConfig(env, name, ""),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVMExists(env, resourceName, &vm),
+					resource.TestCheckResourceAttr(resourceName, "name", name),
+				),
+			},
+		},
+	})
+}
+
+// TestAccVM_disappears deletes the VM behind Terraform's back and expects the
+// next plan to create it again.
+func TestAccVM_disappears(t *testing.T) {
+	env := acctest.New(t)
+	name := acctest.RandomName()
+	resourceName := "acecloud_vm.test"
+	var vm types.VM
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { env.PreCheck(t) },
+		ProtoV6ProviderFactories: env.ProtoV6ProviderFactories(),
+		CheckDestroy:             testAccCheckVMDestroy(env),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVMConfig(env, name, ""),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVMExists(env, resourceName, &vm),
+					func(*terraform.State) error {
+						return env.Client().DeleteVMs(context.Background(), []string{vm.ID})
+					},
+					testAccCheckVMGone(env, &vm),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestAccVM_vmCount(t *testing.T) {
+	env := acctest.New(t)
+	env.SkipUnlessFake(t)
+	name := acctest.RandomName()
+	resourceName := "acecloud_vm.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { env.PreCheck(t) },
+		ProtoV6ProviderFactories: env.ProtoV6ProviderFactories(),
+		CheckDestroy:             testAccCheckVMDestroy(env),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVMConfig(env, name, "vm_count = 3"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVMExists(env, resourceName, nil),
+					resource.TestCheckResourceAttr(resourceName, "vm_count", "3"),
+					// The resource manages the first instance of the batch;
+					// the API creates the others alongside it.
+					func(*terraform.State) error {
+						var count int
+						for _, vm := range env.Fake.Instances() {
+							if strings.HasPrefix(vm.Name, name) {
+								count++
+							}
+						}
+						if count != 3 {
+							return fmt.Errorf("API has %d instances named %s*, want 3", count, name)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+// TestAccVM_timeouts holds the fake API's status transitions for longer than
+// the configured timeouts.
+func TestAccVM_timeouts(t *testing.T) {
+	env := acctest.New(t)
+	env.SkipUnlessFake(t)
+	name := acctest.RandomName()
+	resourceName := "acecloud_vm.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { env.PreCheck(t) },
+		ProtoV6ProviderFactories: env.ProtoV6ProviderFactories(),
+		CheckDestroy:             testAccCheckVMDestroy(env),
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() { env.Fake.SetTransitionDelay(time.Hour) },
+				Config: testAccVMConfig(env, name, `
+  timeouts {
+    create = "2s"
+  }`),
+				ExpectError: regexp.MustCompile(`error waiting for VM \(.+\) to be created`),
+			},
+			{
+				PreConfig: func() { env.Fake.SetTransitionDelay(0) },
+				Config: testAccVMConfig(env, name, `
+  timeouts {
+    create = "2s"
+  }`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVMExists(env, resourceName, nil),
+					resource.TestCheckResourceAttr(resourceName, "status", "ACTIVE"),
+				),
+			},
+			{
+				PreConfig: func() { env.Fake.SetTransitionDelay(time.Hour) },
+				Config: testAccVMConfig(env, name, `
+  timeouts {
+    create = "2s"
+    delete = "2s"
+  }`),
+			},
+			{
+				Config:      testAccNoResourcesConfig(env),
+				ExpectError: regexp.MustCompile(`error waiting for VM \(.+\) to be deleted`),
+			},
+			{
+				PreConfig: func() { env.Fake.SetTransitionDelay(0) },
+				Config:    testAccNoResourcesConfig(env),
+			},
+		},
+	})
+}
+
+func testAccVMConfig(env *acctest.Env, name, extra string) string {
+	return env.ProviderConfig() + fmt.Sprintf(`
+resource "acecloud_vm" "test" {
+  name      = %q
+  flavor    = %q
+  boot_uuid = %q
+  key       = %q
+  %s
+}
+`, name, testAccEnv("ACECLOUD_TEST_FLAVOR", "C.2x4"), testAccEnv("ACECLOUD_TEST_IMAGE", "image-1"), testAccEnv("ACECLOUD_TEST_KEY", "tf-acc-key"), extra)
+}
+
+// testAccNoResourcesConfig removes every resource. The comment keeps the
+// configuration non-empty when the provider block is.
+func testAccNoResourcesConfig(env *acctest.Env) string {
+	return env.ProviderConfig() + "\n# No resources.\n"
+}
+
+// testAccEnv returns the environment variable name, or def when it is unset.
+// Tests against a real endpoint set the flavor, image and key to use.
+func testAccEnv(name, def string) string {
+	if v := os.Getenv(name); v != "" {
+		return v
+	}
+	return def
+}
+
+func testAccCheckVMExists(env *acctest.Env, resourceName string, vm *types.VM) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("%s not found in state", resourceName)
+		}
+
+		got, err := env.Client().GetVM(context.Background(), rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if vm != nil {
+			*vm = *got
+		}
+		return nil
+	}
+}
+
+func testAccCheckVMGone(env *acctest.Env, vm *types.VM) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		c := env.Client()
+		for deadline := time.Now().Add(10 * time.Minute); time.Now().Before(deadline); time.Sleep(5 * time.Second) {
+			if _, err := c.GetVM(context.Background(), vm.ID); client.IsNotFound(err) {
+				return nil
+			}
+		}
+		return fmt.Errorf("VM %s still exists", vm.ID)
+	}
+}
+
+func testAccCheckVMDestroy(env *acctest.Env) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "acecloud_vm" {
+				continue
+			}
+
+			_, err := env.Client().GetVM(context.Background(), rs.Primary.ID)
+			if err == nil {
+				return fmt.Errorf("VM %s still exists", rs.Primary.ID)
+			}
+			if !client.IsNotFound(err) {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func testAccVMQualifiedImportID(resourceName string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return "", fmt.Errorf("%s not found in state", resourceName)
+		}
+		a := rs.Primary.Attributes
+		return strings.Join([]string{a["region"], a["project_id"], rs.Primary.ID}, "/"), nil
+	}
+}
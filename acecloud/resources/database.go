@@ -0,0 +1,119 @@
+package resources
+
+import (
+	"context"
+
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/client/types"
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/helpers"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ResourceAceCloudDatabase manages a logical database on a managed database
+// instance. The ID has the form "<instance_id>/<name>".
+func ResourceAceCloudDatabase() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceAceCloudDatabaseCreate,
+		ReadContext:   resourceAceCloudDatabaseRead,
+		DeleteContext: resourceAceCloudDatabaseDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: helpers.ImportStateScoped(2),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"instance_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the database instance the database belongs to",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the database",
+			},
+			"charset": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "Character set of the database. Defaults to the engine default",
+			},
+			"collation": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "Collation of the database. Defaults to the engine default",
+			},
+			"region":     helpers.RegionSchema(),
+			"project_id": helpers.ProjectIDSchema(),
+		},
+	}
+}
+
+func resourceAceCloudDatabaseCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := helpers.ScopedClient(d, meta)
+
+	instanceID := d.Get("instance_id").(string)
+	req := &types.DatabaseCreateRequest{
+		Name:      d.Get("name").(string),
+		Charset:   d.Get("charset").(string),
+		Collation: d.Get("collation").(string),
+	}
+
+	resp, err := c.CreateDatabase(ctx, instanceID, req)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(helpers.BuildCompositeID(instanceID, resp.Name))
+
+	return resourceAceCloudDatabaseRead(ctx, d, meta)
+}
+
+func resourceAceCloudDatabaseRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := helpers.ScopedClient(d, meta)
+
+	parts, err := helpers.ParseCompositeID(d.Id(), 2)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	instanceID, name := parts[0], parts[1]
+
+	resp, err := c.GetDatabase(ctx, instanceID, name)
+	if err != nil {
+		if helpers.IsNotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	_ = d.Set("instance_id", instanceID)
+	_ = d.Set("name", resp.Name)
+	_ = d.Set("charset", resp.Charset)
+	_ = d.Set("collation", resp.Collation)
+
+	helpers.SetScope(d, c)
+
+	return nil
+}
+
+func resourceAceCloudDatabaseDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := helpers.ScopedClient(d, meta)
+
+	err := c.DeleteDatabase(ctx, d.Get("instance_id").(string), d.Get("name").(string))
+	if err != nil {
+		if helpers.IsNotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return nil
+}
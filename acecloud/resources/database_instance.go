@@ -0,0 +1,381 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/client"
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/client/types"
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/helpers"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	databaseInstanceStatusActive    = "active"
+	databaseInstanceStatusCreating  = "creating"
+	databaseInstanceStatusUpgrading = "upgrading"
+	databaseInstanceStatusResizing  = "resizing"
+	databaseInstanceStatusUpdating  = "updating"
+	databaseInstanceStatusBackingUp = "backing_up"
+	databaseInstanceStatusDeleting  = "deleting"
+	databaseInstanceStatusError     = "error"
+)
+
+// databaseInstanceDeletePending are the statuses an instance may report
+// between a delete request and its disappearance. A backup or update that was
+// running when the delete was accepted finishes first. active is only allowed
+// for databaseInstanceDeleteGracePeriod.
+var databaseInstanceDeletePending = []string{
+	databaseInstanceStatusActive,
+	databaseInstanceStatusCreating,
+	databaseInstanceStatusUpgrading,
+	databaseInstanceStatusResizing,
+	databaseInstanceStatusUpdating,
+	databaseInstanceStatusBackingUp,
+	databaseInstanceStatusDeleting,
+}
+
+// databaseInstanceDeleteGracePeriod is how long an instance may still report
+// active after its delete was accepted. An instance that is active after that
+// did not take the delete, and waiting for the whole delete timeout would only
+// hide it.
+const databaseInstanceDeleteGracePeriod = 2 * time.Minute
+
+var backupWindowRegexp = regexp.MustCompile(`^([01]\d|2[0-3]):[0-5]\d-([01]\d|2[0-3]):[0-5]\d$`)
+
+func ResourceAceCloudDatabaseInstance() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceAceCloudDatabaseInstanceCreate,
+		ReadContext:   resourceAceCloudDatabaseInstanceRead,
+		UpdateContext: resourceAceCloudDatabaseInstanceUpdate,
+		DeleteContext: resourceAceCloudDatabaseInstanceDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: helpers.ImportStateScoped(1),
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+			Update: schema.DefaultTimeout(60 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		// Storage can only grow; shrinking requires a new instance.
+		CustomizeDiff: customdiff.ForceNewIfChange("storage_size", func(ctx context.Context, old, new, meta interface{}) bool {
+			return new.(int) < old.(int)
+		}),
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the database instance",
+			},
+			"engine": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "Database engine, either postgres or mysql",
+				ValidateFunc: validation.StringInSlice([]string{"postgres", "mysql"}, false),
+			},
+			"version": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Engine version. Changing this performs an in-place major or minor version upgrade",
+			},
+			"flavor": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Flavor ID determining the CPU and memory of the instance",
+			},
+			"storage_size": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				Description:  "Storage size in GB. Storage can be increased in place; decreasing it replaces the instance",
+				ValidateFunc: validation.IntAtLeast(10),
+			},
+			"storage_type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "Volume type used for the instance storage",
+			},
+			"high_availability": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether a standby replica is provisioned in another availability zone",
+			},
+			"backup_window": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Daily UTC time range for automated backups, in the format hh24:mi-hh24:mi",
+				ValidateFunc: validation.StringMatch(backupWindowRegexp, "must be in the format hh24:mi-hh24:mi"),
+			},
+			"backup_retention_days": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      7,
+				Description:  "Number of days automated backups are kept. 0 disables automated backups",
+				ValidateFunc: validation.IntBetween(0, 35),
+			},
+			"allowed_networks": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "List of network IDs or CIDR blocks allowed to connect to the instance",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"admin_username": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "Name of the administrative user",
+			},
+			"admin_password": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				WriteOnly:   true,
+				Description: "Password of the administrative user. It is only sent when the instance is created and is never stored in state. Requires Terraform 1.11 or later. When not set AceCloud generates a password that the provider does not keep",
+			},
+			"admin_password_version": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Version of admin_password. The password cannot be changed in place, so changing this replaces the instance with one created with the current admin_password",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Current status of the database instance",
+			},
+			"host": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Hostname clients connect to",
+			},
+			"port": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Port clients connect to",
+			},
+			"created_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Creation timestamp of the database instance",
+			},
+			"region":     helpers.RegionSchema(),
+			"project_id": helpers.ProjectIDSchema(),
+		},
+	}
+}
+
+func resourceAceCloudDatabaseInstanceCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := helpers.ScopedClient(d, meta)
+
+	req, diags := databaseInstanceCreateRequest(d)
+	if diags.HasError() {
+		return diags
+	}
+
+	resp, err := c.CreateDatabaseInstance(ctx, req)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(resp.ID)
+
+	if err := waitForDatabaseInstance(ctx, c, d.Id(), []string{databaseInstanceStatusCreating, databaseInstanceStatusBackingUp}, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return diag.Errorf("error waiting for database instance (%s) to be created: %s", d.Id(), err)
+	}
+
+	return resourceAceCloudDatabaseInstanceRead(ctx, d, meta)
+}
+
+func databaseInstanceCreateRequest(d *schema.ResourceData) (*types.DatabaseInstanceCreateRequest, diag.Diagnostics) {
+	req := &types.DatabaseInstanceCreateRequest{
+		Name:                d.Get("name").(string),
+		Engine:              d.Get("engine").(string),
+		Version:             d.Get("version").(string),
+		Flavor:              d.Get("flavor").(string),
+		StorageSize:         d.Get("storage_size").(int),
+		StorageType:         d.Get("storage_type").(string),
+		HighAvailability:    d.Get("high_availability").(bool),
+		BackupWindow:        d.Get("backup_window").(string),
+		BackupRetentionDays: d.Get("backup_retention_days").(int),
+		AdminUsername:       d.Get("admin_username").(string),
+	}
+	if v, ok := d.GetOk("allowed_networks"); ok && v != nil {
+		req.AllowedNetworks = helpers.InterfaceSliceToStringSlice(v.([]interface{}))
+	}
+	// admin_password is write-only, so it is only available in the config.
+	password, diags := d.GetRawConfigAt(cty.GetAttrPath("admin_password"))
+	if diags.HasError() {
+		return nil, diags
+	}
+	if !password.IsNull() {
+		req.AdminPassword = password.AsString()
+	}
+
+	return req, nil
+}
+
+func resourceAceCloudDatabaseInstanceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := helpers.ScopedClient(d, meta)
+
+	resp, err := c.GetDatabaseInstance(ctx, d.Id())
+	if err != nil {
+		if helpers.IsNotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	db := resp
+	_ = d.Set("name", db.Name)
+	_ = d.Set("engine", db.Engine)
+	_ = d.Set("version", db.Version)
+	_ = d.Set("flavor", db.Flavor)
+	_ = d.Set("storage_size", db.StorageSize)
+	_ = d.Set("storage_type", db.StorageType)
+	_ = d.Set("high_availability", db.HighAvailability)
+	_ = d.Set("backup_window", db.BackupWindow)
+	_ = d.Set("backup_retention_days", db.BackupRetentionDays)
+	_ = d.Set("allowed_networks", db.AllowedNetworks)
+	_ = d.Set("admin_username", db.AdminUsername)
+	_ = d.Set("status", db.Status)
+	_ = d.Set("host", db.Host)
+	_ = d.Set("port", db.Port)
+	_ = d.Set("created_at", db.CreatedAt)
+
+	helpers.SetScope(d, c)
+
+	return nil
+}
+
+func resourceAceCloudDatabaseInstanceUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := helpers.ScopedClient(d, meta)
+
+	if d.HasChanges("version", "flavor", "storage_size", "high_availability", "backup_window", "backup_retention_days", "allowed_networks") {
+		req := &types.DatabaseInstanceUpdateRequest{
+			Version:             d.Get("version").(string),
+			Flavor:              d.Get("flavor").(string),
+			StorageSize:         d.Get("storage_size").(int),
+			HighAvailability:    d.Get("high_availability").(bool),
+			BackupWindow:        d.Get("backup_window").(string),
+			BackupRetentionDays: d.Get("backup_retention_days").(int),
+			AllowedNetworks:     helpers.InterfaceSliceToStringSlice(d.Get("allowed_networks").([]interface{})),
+		}
+
+		_, err := c.UpdateDatabaseInstance(ctx, d.Id(), req)
+		if err != nil {
+			if helpers.IsNotFoundError(err) {
+				d.SetId("")
+				return nil
+			}
+			return diag.FromErr(err)
+		}
+
+		pending := []string{
+			databaseInstanceStatusUpgrading,
+			databaseInstanceStatusResizing,
+			databaseInstanceStatusUpdating,
+			databaseInstanceStatusBackingUp,
+		}
+		if err := waitForDatabaseInstance(ctx, c, d.Id(), pending, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return diag.Errorf("error waiting for database instance (%s) to be updated: %s", d.Id(), err)
+		}
+	}
+
+	return resourceAceCloudDatabaseInstanceRead(ctx, d, meta)
+}
+
+func resourceAceCloudDatabaseInstanceDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := helpers.ScopedClient(d, meta)
+
+	err := c.DeleteDatabaseInstance(ctx, d.Id())
+	if err != nil {
+		if helpers.IsNotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	if _, err := databaseInstanceDeleteStateConf(ctx, c, d.Id(), d.Timeout(schema.TimeoutDelete)).WaitForStateContext(ctx); err != nil {
+		return diag.Errorf("error waiting for database instance (%s) to be deleted: %s", d.Id(), err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// databaseInstanceDeleteStateConf waits for a deleted instance to disappear.
+func databaseInstanceDeleteStateConf(ctx context.Context, c *client.AceCloudClient, id string, timeout time.Duration) *retry.StateChangeConf {
+	return &retry.StateChangeConf{
+		Pending:    databaseInstanceDeletePending,
+		Target:     []string{},
+		Refresh:    databaseInstanceDeleteRefreshFunc(ctx, c, id, time.Now().Add(databaseInstanceDeleteGracePeriod)),
+		Timeout:    timeout,
+		Delay:      10 * time.Second,
+		MinTimeout: 5 * time.Second,
+	}
+}
+
+// databaseInstanceDeleteRefreshFunc is databaseInstanceStatusRefreshFunc
+// failing once the instance is still active after activeUntil.
+func databaseInstanceDeleteRefreshFunc(ctx context.Context, c *client.AceCloudClient, id string, activeUntil time.Time) retry.StateRefreshFunc {
+	refresh := databaseInstanceStatusRefreshFunc(ctx, c, id)
+	return func() (interface{}, string, error) {
+		resp, status, err := refresh()
+		if err == nil && status == databaseInstanceStatusActive && time.Now().After(activeUntil) {
+			return resp, status, fmt.Errorf("database instance is still active %s after the delete request", databaseInstanceDeleteGracePeriod)
+		}
+		return resp, status, err
+	}
+}
+
+// waitForDatabaseInstance blocks until the instance leaves the given pending
+// statuses and reaches the active status.
+func waitForDatabaseInstance(ctx context.Context, c *client.AceCloudClient, id string, pending []string, timeout time.Duration) error {
+	stateConf := &retry.StateChangeConf{
+		Pending:    pending,
+		Target:     []string{databaseInstanceStatusActive},
+		Refresh:    databaseInstanceStatusRefreshFunc(ctx, c, id),
+		Timeout:    timeout,
+		Delay:      10 * time.Second,
+		MinTimeout: 5 * time.Second,
+	}
+	_, err := stateConf.WaitForStateContext(ctx)
+	return err
+}
+
+func databaseInstanceStatusRefreshFunc(ctx context.Context, c *client.AceCloudClient, id string) retry.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		resp, err := c.GetDatabaseInstance(ctx, id)
+		if err != nil {
+			if helpers.IsNotFoundError(err) {
+				return nil, "", nil
+			}
+			return nil, "", err
+		}
+		if resp.Status == databaseInstanceStatusError {
+			return resp, resp.Status, fmt.Errorf("database instance entered error status")
+		}
+		return resp, resp.Status, nil
+	}
+}
@@ -0,0 +1,226 @@
+package resources
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+const testDatabaseInstance = `{"data":{
+	"id": "db-1",
+	"name": "orders",
+	"engine": "postgres",
+	"version": "16",
+	"flavor": "db.m.2x8",
+	"storage_size": 50,
+	"storage_type": "ssd",
+	"high_availability": true,
+	"backup_window": "02:00-03:00",
+	"backup_retention_days": 7,
+	"allowed_networks": ["10.0.0.0/16"],
+	"admin_username": "admin",
+	"status": "active",
+	"host": "db-1.dbaas.test-region.acecloud.test",
+	"port": 5432,
+	"created_at": "2026-01-01T00:00:00Z"
+}}`
+
+var testDatabaseInstanceConfig = map[string]interface{}{
+	"name":              "orders",
+	"engine":            "postgres",
+	"version":           "16",
+	"flavor":            "db.m.2x8",
+	"storage_size":      50,
+	"high_availability": true,
+	"allowed_networks":  []interface{}{"10.0.0.0/16"},
+}
+
+func TestDatabaseInstanceImport(t *testing.T) {
+	api := newStubAPI()
+	api.handle("GET /dbaas/instances/db-1", http.StatusOK, testDatabaseInstance)
+	r := ResourceAceCloudDatabaseInstance()
+
+	d, diags := importState(t, r, "db-1", api.client())
+	if diags.HasError() {
+		t.Fatalf("import: %v", diags)
+	}
+	wantRoutes(t, api, "GET /dbaas/instances/db-1 test-region")
+	if d.Get("engine") != "postgres" || d.Get("storage_size") != 50 || d.Get("host") != "db-1.dbaas.test-region.acecloud.test" || d.Get("port") != 5432 || d.Get("allowed_networks.0") != "10.0.0.0/16" {
+		t.Errorf("imported engine %q, storage_size %v, host %q, port %v, allowed_networks %v", d.Get("engine"), d.Get("storage_size"), d.Get("host"), d.Get("port"), d.Get("allowed_networks"))
+	}
+
+	diff, err := r.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(testDatabaseInstanceConfig), api.client())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !diff.Empty() {
+		t.Errorf("diff after import = %v, want none", diffAttributes(diff))
+	}
+}
+
+// An import ID prefixed with region and project_id reads the instance there.
+func TestDatabaseInstanceImportScoped(t *testing.T) {
+	api := newStubAPI()
+	api.handle("GET /dbaas/instances/db-1", http.StatusOK, testDatabaseInstance)
+	r := ResourceAceCloudDatabaseInstance()
+
+	d, diags := importState(t, r, "other-region/other-project/db-1", api.client())
+	if diags.HasError() {
+		t.Fatalf("import: %v", diags)
+	}
+	wantRoutes(t, api, "GET /dbaas/instances/db-1 other-region")
+	if d.Id() != "db-1" || d.Get("region") != "other-region" || d.Get("project_id") != "other-project" {
+		t.Errorf("imported ID %q, region %q, project_id %q", d.Id(), d.Get("region"), d.Get("project_id"))
+	}
+}
+
+// Storage can grow in place but shrinking it replaces the instance.
+func TestDatabaseInstanceStorageDiff(t *testing.T) {
+	api := newStubAPI()
+	api.handle("GET /dbaas/instances/db-1", http.StatusOK, testDatabaseInstance)
+	r := ResourceAceCloudDatabaseInstance()
+	d, diags := importState(t, r, "db-1", api.client())
+	if diags.HasError() {
+		t.Fatalf("import: %v", diags)
+	}
+
+	for _, tc := range []struct {
+		size        int
+		wantReplace bool
+	}{
+		{100, false},
+		{20, true},
+	} {
+		config := map[string]interface{}{"storage_size": tc.size}
+		for k, v := range testDatabaseInstanceConfig {
+			if k != "storage_size" {
+				config[k] = v
+			}
+		}
+		diff, err := r.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(config), api.client())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff.Attributes["storage_size"] == nil || diff.RequiresNew() != tc.wantReplace {
+			t.Errorf("storage_size 50 -> %d: diff %v, replace %t; want replace %t", tc.size, diffAttributes(diff), diff.RequiresNew(), tc.wantReplace)
+		}
+	}
+}
+
+// admin_password is write-only, so the create request takes it from the
+// config rather than from state.
+func TestDatabaseInstanceAdminPassword(t *testing.T) {
+	r := ResourceAceCloudDatabaseInstance()
+	if err := r.InternalValidate(nil, true); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		config cty.Value
+		want   string
+	}{
+		{cty.StringVal("s3cret-password"), "s3cret-password"},
+		{cty.NullVal(cty.String), ""},
+	} {
+		d := r.Data(&terraform.InstanceState{
+			RawConfig: cty.ObjectVal(map[string]cty.Value{"name": cty.StringVal("orders"), "admin_password": tc.config}),
+		})
+		req, diags := databaseInstanceCreateRequest(d)
+		if diags.HasError() {
+			t.Fatalf("create request: %v", diags)
+		}
+		if req.AdminPassword != tc.want {
+			t.Errorf("admin_password %#v sent as %q, want %q", tc.config, req.AdminPassword, tc.want)
+		}
+	}
+}
+
+func TestDatabaseInstanceReadGone(t *testing.T) {
+	api := newStubAPI()
+	r := ResourceAceCloudDatabaseInstance()
+	d := resourceData(t, r, "db-1", testDatabaseInstanceConfig)
+
+	if diags := r.ReadContext(context.Background(), d, api.client()); diags.HasError() {
+		t.Fatalf("read: %v", diags)
+	}
+	if d.Id() != "" {
+		t.Errorf("ID = %q after the instance disappeared, want it removed from state", d.Id())
+	}
+}
+
+// A backup or update running when the delete is accepted must not end the
+// wait early.
+func TestDatabaseInstanceDeleteWait(t *testing.T) {
+	for _, status := range []string{
+		databaseInstanceStatusBackingUp,
+		databaseInstanceStatusUpdating,
+		databaseInstanceStatusResizing,
+		databaseInstanceStatusUpgrading,
+		databaseInstanceStatusCreating,
+	} {
+		t.Run(status, func(t *testing.T) {
+			// The instance disappears on the third poll.
+			polls := 0
+			api := newStubAPI()
+			api.handlers["GET /dbaas/instances/db-1"] = func(*http.Request) (int, string) {
+				switch polls++; polls {
+				case 1:
+					return http.StatusOK, `{"data":{"id":"db-1","status":"` + status + `"}}`
+				case 2:
+					return http.StatusOK, `{"data":{"id":"db-1","status":"deleting"}}`
+				default:
+					return http.StatusNotFound, `{"error":true,"message":"instance not found"}`
+				}
+			}
+
+			conf := databaseInstanceDeleteStateConf(context.Background(), api.client(), "db-1", time.Minute)
+			conf.Delay, conf.MinTimeout, conf.PollInterval = 0, 0, time.Millisecond
+			if _, err := conf.WaitForStateContext(context.Background()); err != nil {
+				t.Fatalf("waiting for deletion: %s", err)
+			}
+			if polls != 3 {
+				t.Errorf("polled %d times, want 3", polls)
+			}
+		})
+	}
+}
+
+func TestDatabaseInstanceDeleteWaitError(t *testing.T) {
+	api := newStubAPI()
+	api.handle("GET /dbaas/instances/db-1", http.StatusOK, `{"data":{"id":"db-1","status":"error"}}`)
+
+	conf := databaseInstanceDeleteStateConf(context.Background(), api.client(), "db-1", time.Minute)
+	conf.Delay, conf.MinTimeout, conf.PollInterval = 0, 0, time.Millisecond
+	if _, err := conf.WaitForStateContext(context.Background()); err == nil {
+		t.Error("waiting for an instance in error status succeeded")
+	}
+}
+
+// The instance may report active for a short while after the delete request,
+// but not for the whole delete timeout.
+func TestDatabaseInstanceDeleteActive(t *testing.T) {
+	polls := 0
+	api := newStubAPI()
+	api.handlers["GET /dbaas/instances/db-1"] = func(*http.Request) (int, string) {
+		if polls++; polls == 1 {
+			return http.StatusOK, `{"data":{"id":"db-1","status":"active"}}`
+		}
+		return http.StatusNotFound, `{"error":true,"message":"instance not found"}`
+	}
+	conf := databaseInstanceDeleteStateConf(context.Background(), api.client(), "db-1", time.Minute)
+	conf.Delay, conf.MinTimeout, conf.PollInterval = 0, 0, time.Millisecond
+	if _, err := conf.WaitForStateContext(context.Background()); err != nil {
+		t.Fatalf("waiting for deletion: %s", err)
+	}
+
+	api.handle("GET /dbaas/instances/db-1", http.StatusOK, `{"data":{"id":"db-1","status":"active"}}`)
+	refresh := databaseInstanceDeleteRefreshFunc(context.Background(), api.client(), "db-1", time.Now().Add(-time.Second))
+	if _, _, err := refresh(); err == nil || !strings.Contains(err.Error(), "still active") {
+		t.Errorf("refresh after the grace period = %v, want a still active error", err)
+	}
+}
@@ -0,0 +1,82 @@
+package resources
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestDatabaseCreate(t *testing.T) {
+	api := newStubAPI()
+	api.handle("POST /dbaas/instances/db-1/databases", http.StatusOK, `{"data":{"name":"app"}}`)
+	api.handle("GET /dbaas/instances/db-1/databases/app", http.StatusOK, `{"data":{"name":"app","charset":"UTF8","collation":"en_US.UTF-8"}}`)
+	r := ResourceAceCloudDatabase()
+	d := resourceData(t, r, "", map[string]interface{}{"instance_id": "db-1", "name": "app", "charset": "UTF8"})
+
+	if diags := r.CreateContext(context.Background(), d, api.client()); diags.HasError() {
+		t.Fatalf("create: %v", diags)
+	}
+	wantRoutes(t, api,
+		"POST /dbaas/instances/db-1/databases test-region",
+		"GET /dbaas/instances/db-1/databases/app test-region",
+	)
+	if api.bodies[0] != `{"name":"app","charset":"UTF8"}` {
+		t.Errorf("create request body = %s", api.bodies[0])
+	}
+	if d.Id() != "db-1/app" || d.Get("collation") != "en_US.UTF-8" {
+		t.Errorf("created %q with collation %q", d.Id(), d.Get("collation"))
+	}
+}
+
+func TestDatabaseImport(t *testing.T) {
+	api := newStubAPI()
+	api.handle("GET /dbaas/instances/db-1/databases/app", http.StatusOK, `{"data":{"name":"app","charset":"UTF8","collation":"en_US.UTF-8"}}`)
+	r := ResourceAceCloudDatabase()
+
+	d, diags := importState(t, r, "db-1/app", api.client())
+	if diags.HasError() {
+		t.Fatalf("import: %v", diags)
+	}
+	if d.Get("instance_id") != "db-1" || d.Get("name") != "app" || d.Get("collation") != "en_US.UTF-8" {
+		t.Errorf("imported instance_id %q, name %q, collation %q", d.Get("instance_id"), d.Get("name"), d.Get("collation"))
+	}
+
+	d, diags = importState(t, r, "other-region/other-project/db-1/app", api.client())
+	if diags.HasError() {
+		t.Fatalf("import: %v", diags)
+	}
+	if d.Id() != "db-1/app" || d.Get("region") != "other-region" || d.Get("project_id") != "other-project" {
+		t.Errorf("imported ID %q, region %q, project_id %q", d.Id(), d.Get("region"), d.Get("project_id"))
+	}
+
+	for _, id := range []string{"db-1", "/app", "db-1/", "db-1/app/extra"} {
+		if _, diags := importState(t, r, id, api.client()); !diags.HasError() {
+			t.Errorf("import of %q succeeded", id)
+		}
+	}
+}
+
+func TestDatabaseReadGone(t *testing.T) {
+	api := newStubAPI()
+	r := ResourceAceCloudDatabase()
+	d := resourceData(t, r, "db-1/app", map[string]interface{}{"instance_id": "db-1", "name": "app"})
+
+	if diags := r.ReadContext(context.Background(), d, api.client()); diags.HasError() {
+		t.Fatalf("read: %v", diags)
+	}
+	if d.Id() != "" {
+		t.Errorf("ID = %q after the database disappeared, want it removed from state", d.Id())
+	}
+}
+
+func TestDatabaseDelete(t *testing.T) {
+	api := newStubAPI()
+	api.handle("DELETE /dbaas/instances/db-1/databases/app", http.StatusOK, `{"data":{}}`)
+	r := ResourceAceCloudDatabase()
+	d := resourceData(t, r, "db-1/app", map[string]interface{}{"instance_id": "db-1", "name": "app"})
+
+	if diags := r.DeleteContext(context.Background(), d, api.client()); diags.HasError() {
+		t.Fatalf("delete: %v", diags)
+	}
+	wantRoutes(t, api, "DELETE /dbaas/instances/db-1/databases/app test-region")
+}
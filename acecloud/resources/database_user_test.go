@@ -0,0 +1,92 @@
+package resources
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestDatabaseUserCreate(t *testing.T) {
+	api := newStubAPI()
+	api.handle("POST /dbaas/instances/db-1/users", http.StatusOK, `{"data":{"name":"app_user"}}`)
+	api.handle("GET /dbaas/instances/db-1/users/app_user", http.StatusOK, `{"data":{"name":"app_user"}}`)
+	r := ResourceAceCloudDatabaseUser()
+	d := resourceData(t, r, "", map[string]interface{}{"instance_id": "db-1", "name": "app_user", "password": "s3cret-password"})
+
+	if diags := r.CreateContext(context.Background(), d, api.client()); diags.HasError() {
+		t.Fatalf("create: %v", diags)
+	}
+	wantRoutes(t, api,
+		"POST /dbaas/instances/db-1/users test-region",
+		"GET /dbaas/instances/db-1/users/app_user test-region",
+	)
+	if api.bodies[0] != `{"name":"app_user","password":"s3cret-password"}` {
+		t.Errorf("create request body = %s", api.bodies[0])
+	}
+	if d.Id() != "db-1/app_user" || d.Get("password") != "s3cret-password" {
+		t.Errorf("created %q with password %q", d.Id(), d.Get("password"))
+	}
+}
+
+// An imported user has no password in state. The first plan updates it in
+// place to the configured password, and after that apply there is no diff.
+func TestDatabaseUserImportPassword(t *testing.T) {
+	ctx := context.Background()
+	api := newStubAPI()
+	api.handle("GET /dbaas/instances/db-1/users/app_user", http.StatusOK, `{"data":{"name":"app_user"}}`)
+	api.handle("PUT /dbaas/instances/db-1/users/app_user", http.StatusOK, `{"data":{"name":"app_user"}}`)
+	r := ResourceAceCloudDatabaseUser()
+
+	d, diags := importState(t, r, "db-1/app_user", api.client())
+	if diags.HasError() {
+		t.Fatalf("import: %v", diags)
+	}
+	if d.Get("instance_id") != "db-1" || d.Get("name") != "app_user" || d.Get("password") != "" {
+		t.Errorf("imported instance_id %q, name %q, password %q", d.Get("instance_id"), d.Get("name"), d.Get("password"))
+	}
+
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{"instance_id": "db-1", "name": "app_user", "password": "s3cret-password"})
+	state := d.State()
+	diff, err := r.Diff(ctx, state, config, api.client())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff.RequiresNew() || len(diffAttributes(diff)) != 1 || diff.Attributes["password"] == nil {
+		t.Fatalf("diff after import = %v, want an in-place password update", diffAttributes(diff))
+	}
+
+	api.requests, api.bodies = nil, nil
+	state, diags = r.Apply(ctx, state, diff, api.client())
+	if diags.HasError() {
+		t.Fatalf("apply: %v", diags)
+	}
+	wantRoutes(t, api,
+		"PUT /dbaas/instances/db-1/users/app_user test-region",
+		"GET /dbaas/instances/db-1/users/app_user test-region",
+	)
+	if api.bodies[0] != `{"password":"s3cret-password"}` {
+		t.Errorf("update request body = %s", api.bodies[0])
+	}
+
+	diff, err = r.Diff(ctx, state, config, api.client())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !diff.Empty() {
+		t.Errorf("diff after the first apply = %v, want none", diffAttributes(diff))
+	}
+}
+
+func TestDatabaseUserDelete(t *testing.T) {
+	api := newStubAPI()
+	api.handle("DELETE /dbaas/instances/db-1/users/app_user", http.StatusOK, `{"data":{}}`)
+	r := ResourceAceCloudDatabaseUser()
+	d := resourceData(t, r, "db-1/app_user", map[string]interface{}{"instance_id": "db-1", "name": "app_user", "password": "s3cret-password"})
+
+	if diags := r.DeleteContext(context.Background(), d, api.client()); diags.HasError() {
+		t.Fatalf("delete: %v", diags)
+	}
+	wantRoutes(t, api, "DELETE /dbaas/instances/db-1/users/app_user test-region")
+}
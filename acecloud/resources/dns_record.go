@@ -0,0 +1,246 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/client/types"
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/helpers"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var dnsRecordTypes = []string{"A", "AAAA", "CNAME", "MX", "TXT", "SRV"}
+
+// ResourceAceCloudDNSRecord manages a DNS record set: every value of one name
+// and type within a zone. The ID has the form "<zone_id>/<name>/<type>".
+func ResourceAceCloudDNSRecord() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceAceCloudDNSRecordCreate,
+		ReadContext:   resourceAceCloudDNSRecordRead,
+		UpdateContext: resourceAceCloudDNSRecordUpdate,
+		DeleteContext: resourceAceCloudDNSRecordDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceAceCloudDNSRecordImport,
+		},
+
+		CustomizeDiff: resourceAceCloudDNSRecordCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"zone_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the DNS zone the record set belongs to",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the record set relative to the zone. Use @ for the zone apex",
+				// DNS names are case-insensitive, and the API may return the
+				// name with a trailing dot.
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return strings.EqualFold(strings.TrimSuffix(old, "."), strings.TrimSuffix(new, "."))
+				},
+			},
+			"type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "Record type: A, AAAA, CNAME, MX, TXT or SRV",
+				ValidateFunc: validation.StringInSlice(dnsRecordTypes, false),
+			},
+			"ttl": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      300,
+				Description:  "Time to live of the records in seconds",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"records": {
+				Type:     schema.TypeSet,
+				Required: true,
+				MinItems: 1,
+				Description: "Values of the record set. MX values have the form \"<priority> <host>\" and " +
+					"SRV values the form \"<priority> <weight> <port> <target>\"",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"fqdn": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Fully qualified domain name of the record set",
+			},
+			"region":     helpers.RegionSchema(),
+			"project_id": helpers.ProjectIDSchema(),
+		},
+	}
+}
+
+func resourceAceCloudDNSRecordCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := helpers.ScopedClient(d, meta)
+
+	zoneID := d.Get("zone_id").(string)
+	req := &types.DNSRecordSetCreateRequest{
+		Name:    d.Get("name").(string),
+		Type:    d.Get("type").(string),
+		TTL:     d.Get("ttl").(int),
+		Records: helpers.InterfaceSliceToStringSlice(d.Get("records").(*schema.Set).List()),
+	}
+
+	_, err := c.CreateDNSRecordSet(ctx, zoneID, req)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(helpers.BuildCompositeID(zoneID, req.Name, req.Type))
+
+	return resourceAceCloudDNSRecordRead(ctx, d, meta)
+}
+
+func resourceAceCloudDNSRecordRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := helpers.ScopedClient(d, meta)
+
+	parts, err := helpers.ParseCompositeID(d.Id(), 3)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	zoneID, name, recordType := parts[0], parts[1], parts[2]
+
+	resp, err := c.GetDNSRecordSet(ctx, zoneID, name, recordType)
+	if err != nil {
+		if helpers.IsNotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	rs := resp
+	_ = d.Set("zone_id", zoneID)
+	_ = d.Set("name", rs.Name)
+	// Record types are validated in upper case, so the API's is normalized.
+	_ = d.Set("type", strings.ToUpper(rs.Type))
+	_ = d.Set("ttl", rs.TTL)
+	_ = d.Set("records", rs.Records)
+	_ = d.Set("fqdn", rs.FQDN)
+
+	helpers.SetScope(d, c)
+
+	return nil
+}
+
+func resourceAceCloudDNSRecordUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := helpers.ScopedClient(d, meta)
+
+	if d.HasChanges("ttl", "records") {
+		req := &types.DNSRecordSetUpdateRequest{
+			TTL:     d.Get("ttl").(int),
+			Records: helpers.InterfaceSliceToStringSlice(d.Get("records").(*schema.Set).List()),
+		}
+
+		_, err := c.UpdateDNSRecordSet(ctx, d.Get("zone_id").(string), d.Get("name").(string), d.Get("type").(string), req)
+		if err != nil {
+			if helpers.IsNotFoundError(err) {
+				d.SetId("")
+				return nil
+			}
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceAceCloudDNSRecordRead(ctx, d, meta)
+}
+
+func resourceAceCloudDNSRecordDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := helpers.ScopedClient(d, meta)
+
+	err := c.DeleteDNSRecordSet(ctx, d.Get("zone_id").(string), d.Get("name").(string), d.Get("type").(string))
+	if err != nil {
+		if helpers.IsNotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// resourceAceCloudDNSRecordImport accepts "<zone_id>/<name>/<type>" and
+// normalizes the record type to upper case.
+func resourceAceCloudDNSRecordImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts, err := helpers.ParseCompositeID(d.Id(), 3)
+	if err != nil {
+		return nil, fmt.Errorf("invalid import ID, expected <zone_id>/<name>/<type>: %w", err)
+	}
+	recordType := strings.ToUpper(parts[2])
+	if !helpers.StringInSlice(recordType, dnsRecordTypes) {
+		return nil, fmt.Errorf("invalid import ID: unsupported record type %q", parts[2])
+	}
+
+	d.SetId(helpers.BuildCompositeID(parts[0], parts[1], recordType))
+	return []*schema.ResourceData{d}, nil
+}
+
+// resourceAceCloudDNSRecordCustomizeDiff validates record values against the
+// record type at plan time. Values that are not yet known (for example the IP
+// of a VM being created in the same plan) are validated by the API instead.
+func resourceAceCloudDNSRecordCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("records") || !d.NewValueKnown("type") {
+		return nil
+	}
+
+	recordType := d.Get("type").(string)
+	records := helpers.InterfaceSliceToStringSlice(d.Get("records").(*schema.Set).List())
+
+	if recordType == "CNAME" && len(records) > 1 {
+		return fmt.Errorf("a CNAME record set must have exactly one value, got %d", len(records))
+	}
+	for _, r := range records {
+		if err := validateDNSRecordValue(recordType, r); err != nil {
+			return fmt.Errorf("invalid %s record value %q: %w", recordType, r, err)
+		}
+	}
+	return nil
+}
+
+func validateDNSRecordValue(recordType, value string) error {
+	switch recordType {
+	case "A":
+		if ip := net.ParseIP(value); ip == nil || ip.To4() == nil {
+			return fmt.Errorf("must be an IPv4 address")
+		}
+	case "AAAA":
+		if ip := net.ParseIP(value); ip == nil || ip.To4() != nil {
+			return fmt.Errorf("must be an IPv6 address")
+		}
+	case "MX":
+		fields := strings.Fields(value)
+		if len(fields) != 2 {
+			return fmt.Errorf("must have the form \"<priority> <host>\"")
+		}
+		if _, err := strconv.ParseUint(fields[0], 10, 16); err != nil {
+			return fmt.Errorf("priority must be a number between 0 and 65535")
+		}
+	case "SRV":
+		fields := strings.Fields(value)
+		if len(fields) != 4 {
+			return fmt.Errorf("must have the form \"<priority> <weight> <port> <target>\"")
+		}
+		for _, f := range fields[:3] {
+			if _, err := strconv.ParseUint(f, 10, 16); err != nil {
+				return fmt.Errorf("priority, weight and port must be numbers between 0 and 65535")
+			}
+		}
+	}
+	return nil
+}
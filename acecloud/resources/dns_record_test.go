@@ -0,0 +1,177 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/client/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+const testDNSRecordSet = `{"data":{
+	"zone_id": "zone-1",
+	"name": "www",
+	"type": "A",
+	"ttl": 300,
+	"records": ["192.0.2.10", "192.0.2.11"],
+	"fqdn": "www.example.com."
+}}`
+
+var testDNSRecordConfig = map[string]interface{}{
+	"zone_id": "zone-1",
+	"name":    "www",
+	"type":    "A",
+	"records": []interface{}{"192.0.2.10", "192.0.2.11"},
+}
+
+func TestDNSRecordCreate(t *testing.T) {
+	api := newStubAPI()
+	api.handle("POST /dns/zones/zone-1/recordsets", http.StatusOK, testDNSRecordSet)
+	api.handle("GET /dns/zones/zone-1/recordsets/www/A", http.StatusOK, testDNSRecordSet)
+	r := ResourceAceCloudDNSRecord()
+	d := resourceData(t, r, "", testDNSRecordConfig)
+
+	if diags := r.CreateContext(context.Background(), d, api.client()); diags.HasError() {
+		t.Fatalf("create: %v", diags)
+	}
+	wantRoutes(t, api,
+		"POST /dns/zones/zone-1/recordsets test-region",
+		"GET /dns/zones/zone-1/recordsets/www/A test-region",
+	)
+
+	var body types.DNSRecordSetCreateRequest
+	if err := json.Unmarshal([]byte(api.bodies[0]), &body); err != nil {
+		t.Fatal(err)
+	}
+	slices.Sort(body.Records)
+	if body.Name != "www" || body.Type != "A" || body.TTL != 300 || !slices.Equal(body.Records, []string{"192.0.2.10", "192.0.2.11"}) {
+		t.Errorf("create request body = %s", api.bodies[0])
+	}
+	if d.Id() != "zone-1/www/A" || d.Get("fqdn") != "www.example.com." {
+		t.Errorf("created %q with fqdn %q", d.Id(), d.Get("fqdn"))
+	}
+}
+
+func TestDNSRecordImport(t *testing.T) {
+	ctx := context.Background()
+	api := newStubAPI()
+	api.handle("GET /dns/zones/zone-1/recordsets/www/A", http.StatusOK, testDNSRecordSet)
+	r := ResourceAceCloudDNSRecord()
+
+	d, diags := importState(t, r, "zone-1/www/a", api.client())
+	if diags.HasError() {
+		t.Fatalf("import: %v", diags)
+	}
+	if d.Id() != "zone-1/www/A" || d.Get("zone_id") != "zone-1" || d.Get("name") != "www" || d.Get("type") != "A" {
+		t.Errorf("imported %q: zone_id %q, name %q, type %q", d.Id(), d.Get("zone_id"), d.Get("name"), d.Get("type"))
+	}
+	diff, err := r.Diff(ctx, d.State(), terraform.NewResourceConfigRaw(testDNSRecordConfig), api.client())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !diff.Empty() {
+		t.Errorf("diff after import = %v, want none", diffAttributes(diff))
+	}
+
+	for _, tc := range []struct {
+		id      string
+		wantErr string
+	}{
+		{"zone-1/www", "expected <zone_id>/<name>/<type>"},
+		{"zone-1//A", "expected <zone_id>/<name>/<type>"},
+		{"zone-1/www/A/extra", "expected <zone_id>/<name>/<type>"},
+		{"zone-1/www/PTR", `unsupported record type "PTR"`},
+	} {
+		_, diags := importState(t, r, tc.id, api.client())
+		if !diags.HasError() || !strings.Contains(diags[0].Summary, tc.wantErr) {
+			t.Errorf("import of %q returned %v, want an error containing %q", tc.id, diags, tc.wantErr)
+		}
+	}
+}
+
+func TestDNSRecordValidateRecords(t *testing.T) {
+	ctx := context.Background()
+	r := ResourceAceCloudDNSRecord()
+
+	for _, tc := range []struct {
+		recordType string
+		records    []interface{}
+		wantErr    string
+	}{
+		{"A", []interface{}{"192.0.2.10"}, ""},
+		{"A", []interface{}{"2001:db8::1"}, "must be an IPv4 address"},
+		{"AAAA", []interface{}{"192.0.2.10"}, "must be an IPv6 address"},
+		{"CNAME", []interface{}{"a.example.com.", "b.example.com."}, "exactly one value"},
+		{"MX", []interface{}{"10 mail.example.com."}, ""},
+		{"MX", []interface{}{"mail.example.com."}, "<priority> <host>"},
+		{"SRV", []interface{}{"10 5 5060 sip.example.com."}, ""},
+		{"SRV", []interface{}{"10 5 99999 sip.example.com."}, "between 0 and 65535"},
+		{"TXT", []interface{}{"v=spf1 -all"}, ""},
+	} {
+		config := terraform.NewResourceConfigRaw(map[string]interface{}{
+			"zone_id": "zone-1",
+			"name":    "www",
+			"type":    tc.recordType,
+			"records": tc.records,
+		})
+		_, err := r.Diff(ctx, nil, config, nil)
+		switch {
+		case tc.wantErr == "" && err != nil:
+			t.Errorf("%s %v: %s", tc.recordType, tc.records, err)
+		case tc.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tc.wantErr)):
+			t.Errorf("%s %v: error %v, want one containing %q", tc.recordType, tc.records, err, tc.wantErr)
+		}
+	}
+}
+
+// A name and type returned in another form than configured are not a change,
+// which would replace the record set.
+func TestDNSRecordReadNormalized(t *testing.T) {
+	api := newStubAPI()
+	api.handle("GET /dns/zones/zone-1/recordsets/www/A", http.StatusOK, strings.NewReplacer(`"www"`, `"WWW."`, `"A"`, `"a"`).Replace(testDNSRecordSet))
+	r := ResourceAceCloudDNSRecord()
+	d := resourceData(t, r, "zone-1/www/A", testDNSRecordConfig)
+
+	if diags := r.ReadContext(context.Background(), d, api.client()); diags.HasError() {
+		t.Fatalf("read: %v", diags)
+	}
+	if d.Get("type") != "A" {
+		t.Errorf("type = %q, want A", d.Get("type"))
+	}
+	diff, err := r.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(testDNSRecordConfig), api.client())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !diff.Empty() {
+		t.Errorf("diff after read = %v, want none", diffAttributes(diff))
+	}
+}
+
+func TestDNSRecordReadGone(t *testing.T) {
+	api := newStubAPI()
+	r := ResourceAceCloudDNSRecord()
+	d := resourceData(t, r, "zone-1/www/A", testDNSRecordConfig)
+
+	if diags := r.ReadContext(context.Background(), d, api.client()); diags.HasError() {
+		t.Fatalf("read: %v", diags)
+	}
+	if d.Id() != "" {
+		t.Errorf("ID = %q after the record set disappeared, want it removed from state", d.Id())
+	}
+}
+
+func TestDNSRecordDelete(t *testing.T) {
+	api := newStubAPI()
+	api.handle("DELETE /dns/zones/zone-1/recordsets/www/A", http.StatusOK, `{"data":{}}`)
+	r := ResourceAceCloudDNSRecord()
+	d := resourceData(t, r, "zone-1/www/A", testDNSRecordConfig)
+
+	if diags := r.DeleteContext(context.Background(), d, api.client()); diags.HasError() {
+		t.Fatalf("delete: %v", diags)
+	}
+	wantRoutes(t, api, "DELETE /dns/zones/zone-1/recordsets/www/A test-region")
+}
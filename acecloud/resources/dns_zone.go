@@ -0,0 +1,162 @@
+package resources
+
+import (
+	"context"
+	"strings"
+
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/client/types"
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/helpers"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func ResourceAceCloudDNSZone() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceAceCloudDNSZoneCreate,
+		ReadContext:   resourceAceCloudDNSZoneRead,
+		UpdateContext: resourceAceCloudDNSZoneUpdate,
+		DeleteContext: resourceAceCloudDNSZoneDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Domain name of the zone, e.g. example.com",
+				// The API always returns the fully qualified name with a
+				// trailing dot.
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return strings.TrimSuffix(old, ".") == strings.TrimSuffix(new, ".")
+				},
+			},
+			"email": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Administrative contact email published in the SOA record",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Description of the zone",
+			},
+			"ttl": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      3600,
+				Description:  "Default TTL in seconds for the SOA and NS records of the zone",
+				ValidateFunc: validation.IntAtLeast(60),
+			},
+			"serial": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Current SOA serial number of the zone",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Current status of the zone",
+			},
+			"name_servers": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Authoritative name servers to delegate the domain to",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"region":     helpers.RegionSchema(),
+			"project_id": helpers.ProjectIDSchema(),
+		},
+	}
+}
+
+func resourceAceCloudDNSZoneCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := helpers.ScopedClient(d, meta)
+
+	req := &types.DNSZoneCreateRequest{
+		Name:        d.Get("name").(string),
+		Email:       d.Get("email").(string),
+		Description: d.Get("description").(string),
+		TTL:         d.Get("ttl").(int),
+	}
+
+	resp, err := c.CreateDNSZone(ctx, req)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(resp.ID)
+
+	return resourceAceCloudDNSZoneRead(ctx, d, meta)
+}
+
+func resourceAceCloudDNSZoneRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := helpers.ScopedClient(d, meta)
+
+	resp, err := c.GetDNSZone(ctx, d.Id())
+	if err != nil {
+		if helpers.IsNotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	zone := resp
+	_ = d.Set("name", zone.Name)
+	_ = d.Set("email", zone.Email)
+	_ = d.Set("description", zone.Description)
+	_ = d.Set("ttl", zone.TTL)
+	_ = d.Set("serial", zone.Serial)
+	_ = d.Set("status", zone.Status)
+	_ = d.Set("name_servers", zone.NameServers)
+
+	helpers.SetScope(d, c)
+
+	return nil
+}
+
+func resourceAceCloudDNSZoneUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := helpers.ScopedClient(d, meta)
+
+	if d.HasChanges("email", "description", "ttl") {
+		req := &types.DNSZoneUpdateRequest{
+			Email:       d.Get("email").(string),
+			Description: d.Get("description").(string),
+			TTL:         d.Get("ttl").(int),
+		}
+
+		_, err := c.UpdateDNSZone(ctx, d.Id(), req)
+		if err != nil {
+			if helpers.IsNotFoundError(err) {
+				d.SetId("")
+				return nil
+			}
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceAceCloudDNSZoneRead(ctx, d, meta)
+}
+
+func resourceAceCloudDNSZoneDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := helpers.ScopedClient(d, meta)
+
+	err := c.DeleteDNSZone(ctx, d.Id())
+	if err != nil {
+		if helpers.IsNotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,122 @@
+package resources
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+const testDNSZone = `{"data":{
+	"id": "zone-1",
+	"name": "example.com.",
+	"email": "hostmaster@example.com",
+	"description": "public zone",
+	"ttl": 3600,
+	"serial": 2026010101,
+	"status": "active",
+	"name_servers": ["ns1.acecloud.test.", "ns2.acecloud.test."]
+}}`
+
+var testDNSZoneConfig = map[string]interface{}{
+	"name":        "example.com",
+	"email":       "hostmaster@example.com",
+	"description": "public zone",
+}
+
+func TestDNSZoneCreate(t *testing.T) {
+	api := newStubAPI()
+	api.handle("POST /dns/zones", http.StatusOK, `{"data":{"id":"zone-1","name":"example.com."}}`)
+	api.handle("GET /dns/zones/zone-1", http.StatusOK, testDNSZone)
+	r := ResourceAceCloudDNSZone()
+	d := resourceData(t, r, "", testDNSZoneConfig)
+
+	if diags := r.CreateContext(context.Background(), d, api.client()); diags.HasError() {
+		t.Fatalf("create: %v", diags)
+	}
+	wantRoutes(t, api,
+		"POST /dns/zones test-region",
+		"GET /dns/zones/zone-1 test-region",
+	)
+	if want := `{"name":"example.com","email":"hostmaster@example.com","description":"public zone","ttl":3600}`; api.bodies[0] != want {
+		t.Errorf("create request body = %s, want %s", api.bodies[0], want)
+	}
+	if d.Id() != "zone-1" || d.Get("serial") != 2026010101 || d.Get("name_servers.1") != "ns2.acecloud.test." || d.Get("region") != "test-region" {
+		t.Errorf("created %q: serial %v, name_servers %v, region %q", d.Id(), d.Get("serial"), d.Get("name_servers"), d.Get("region"))
+	}
+}
+
+// The API returns the zone name with a trailing dot, which must not replace
+// a zone configured without one.
+func TestDNSZoneImport(t *testing.T) {
+	ctx := context.Background()
+	api := newStubAPI()
+	api.handle("GET /dns/zones/zone-1", http.StatusOK, testDNSZone)
+	r := ResourceAceCloudDNSZone()
+
+	d, diags := importState(t, r, "zone-1", api.client())
+	if diags.HasError() {
+		t.Fatalf("import: %v", diags)
+	}
+	if d.Get("name") != "example.com." || d.Get("ttl") != 3600 {
+		t.Errorf("imported name %q, ttl %v", d.Get("name"), d.Get("ttl"))
+	}
+
+	diff, err := r.Diff(ctx, d.State(), terraform.NewResourceConfigRaw(testDNSZoneConfig), api.client())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !diff.Empty() {
+		t.Errorf("diff after import = %v, want none", diffAttributes(diff))
+	}
+}
+
+func TestDNSZoneReadGone(t *testing.T) {
+	api := newStubAPI()
+	r := ResourceAceCloudDNSZone()
+	d := resourceData(t, r, "zone-1", testDNSZoneConfig)
+
+	if diags := r.ReadContext(context.Background(), d, api.client()); diags.HasError() {
+		t.Fatalf("read: %v", diags)
+	}
+	if d.Id() != "" {
+		t.Errorf("ID = %q after the zone disappeared, want it removed from state", d.Id())
+	}
+}
+
+func TestDNSZoneDelete(t *testing.T) {
+	api := newStubAPI()
+	api.handle("DELETE /dns/zones/zone-1", http.StatusOK, `{"data":{}}`)
+	r := ResourceAceCloudDNSZone()
+	d := resourceData(t, r, "zone-1", testDNSZoneConfig)
+
+	if diags := r.DeleteContext(context.Background(), d, api.client()); diags.HasError() {
+		t.Fatalf("delete: %v", diags)
+	}
+	wantRoutes(t, api, "DELETE /dns/zones/zone-1 test-region")
+}
+
+// A zone with its own region and project is managed there, not in the
+// provider's.
+func TestDNSZoneScope(t *testing.T) {
+	api := newStubAPI()
+	api.handle("POST /dns/zones", http.StatusOK, `{"data":{"id":"zone-1","name":"example.com."}}`)
+	api.handle("GET /dns/zones/zone-1", http.StatusOK, testDNSZone)
+	r := ResourceAceCloudDNSZone()
+	d := resourceData(t, r, "", map[string]interface{}{"name": "example.com", "region": "other-region", "project_id": "other-project"})
+
+	if diags := r.CreateContext(context.Background(), d, api.client()); diags.HasError() {
+		t.Fatalf("create: %v", diags)
+	}
+	wantRoutes(t, api,
+		"POST /dns/zones other-region",
+		"GET /dns/zones/zone-1 other-region",
+	)
+	if got := api.requests[0].URL.Query().Get("project_id"); got != "other-project" {
+		t.Errorf("project_id = %q, want other-project", got)
+	}
+	if d.Get("region") != "other-region" || d.Get("project_id") != "other-project" {
+		t.Errorf("recorded %q/%q", d.Get("region"), d.Get("project_id"))
+	}
+}
@@ -0,0 +1,328 @@
+package resources
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/client/types"
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/helpers"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var bucketNameRegexp = regexp.MustCompile(`^[a-z0-9][a-z0-9.-]*[a-z0-9]$`)
+
+func ResourceAceCloudObjectStorageBucket() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceAceCloudObjectStorageBucketCreate,
+		ReadContext:   resourceAceCloudObjectStorageBucketRead,
+		UpdateContext: resourceAceCloudObjectStorageBucketUpdate,
+		DeleteContext: resourceAceCloudObjectStorageBucketDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceAceCloudObjectStorageBucketImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the bucket, unique within the region",
+				ValidateFunc: validation.All(
+					validation.StringLenBetween(3, 63),
+					validation.StringMatch(bucketNameRegexp, "must contain only lowercase letters, digits, dots and hyphens, and start and end with a letter or digit"),
+				),
+			},
+			"versioning": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether object versioning is enabled",
+			},
+			"acl": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "private",
+				Description:  "Canned ACL applied to the bucket",
+				ValidateFunc: validation.StringInSlice([]string{"private", "public-read", "public-read-write", "authenticated-read"}, false),
+			},
+			"public_access": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether anonymous public access to objects is allowed",
+			},
+			"force_destroy": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Delete all objects when destroying the bucket so that a non-empty bucket can be removed",
+			},
+			"lifecycle_rule": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Lifecycle rules for objects in the bucket",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Unique identifier of the rule",
+						},
+						"enabled": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     true,
+							Description: "Whether the rule is applied",
+						},
+						"prefix": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Object key prefix the rule applies to",
+						},
+						"expiration_days": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Description:  "Days after creation when objects expire",
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+						"noncurrent_version_expiration_days": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Description:  "Days after becoming noncurrent when object versions expire",
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+						"abort_incomplete_multipart_upload_days": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Description:  "Days after initiation when incomplete multipart uploads are aborted",
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+					},
+				},
+			},
+			"cors_rule": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "CORS rules for the bucket",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"allowed_origins": {
+							Type:        schema.TypeList,
+							Required:    true,
+							Description: "Origins allowed to make cross-origin requests",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"allowed_methods": {
+							Type:        schema.TypeList,
+							Required:    true,
+							Description: "HTTP methods allowed for cross-origin requests",
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validation.StringInSlice([]string{"GET", "PUT", "POST", "DELETE", "HEAD"}, false),
+							},
+						},
+						"allowed_headers": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "Headers allowed in preflight requests",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"expose_headers": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "Response headers exposed to the browser",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"max_age_seconds": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Description: "Time in seconds the browser may cache a preflight response",
+						},
+					},
+				},
+			},
+			"endpoint": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "S3-compatible endpoint URL of the bucket",
+			},
+			"created_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Creation timestamp of the bucket",
+			},
+			"region":     helpers.RegionSchema(),
+			"project_id": helpers.ProjectIDSchema(),
+		},
+	}
+}
+
+func resourceAceCloudObjectStorageBucketCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := helpers.ScopedClient(d, meta)
+
+	req := &types.BucketCreateRequest{
+		Name:           d.Get("name").(string),
+		Versioning:     d.Get("versioning").(bool),
+		ACL:            d.Get("acl").(string),
+		PublicAccess:   d.Get("public_access").(bool),
+		LifecycleRules: expandBucketLifecycleRules(d.Get("lifecycle_rule").([]interface{})),
+		CORSRules:      expandBucketCORSRules(d.Get("cors_rule").([]interface{})),
+	}
+
+	resp, err := c.CreateBucket(ctx, req)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(resp.Name)
+
+	return resourceAceCloudObjectStorageBucketRead(ctx, d, meta)
+}
+
+func resourceAceCloudObjectStorageBucketRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := helpers.ScopedClient(d, meta)
+
+	resp, err := c.GetBucket(ctx, d.Id())
+	if err != nil {
+		if helpers.IsNotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	bucket := resp
+	_ = d.Set("name", bucket.Name)
+	_ = d.Set("versioning", bucket.Versioning)
+	_ = d.Set("acl", bucket.ACL)
+	_ = d.Set("public_access", bucket.PublicAccess)
+	_ = d.Set("endpoint", bucket.Endpoint)
+	_ = d.Set("created_at", bucket.CreatedAt)
+	if err := d.Set("lifecycle_rule", flattenBucketLifecycleRules(bucket.LifecycleRules)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("cors_rule", flattenBucketCORSRules(bucket.CORSRules)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	helpers.SetScope(d, c)
+
+	return nil
+}
+
+func resourceAceCloudObjectStorageBucketUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := helpers.ScopedClient(d, meta)
+
+	if d.HasChanges("versioning", "acl", "public_access", "lifecycle_rule", "cors_rule") {
+		req := &types.BucketUpdateRequest{
+			Versioning:     d.Get("versioning").(bool),
+			ACL:            d.Get("acl").(string),
+			PublicAccess:   d.Get("public_access").(bool),
+			LifecycleRules: expandBucketLifecycleRules(d.Get("lifecycle_rule").([]interface{})),
+			CORSRules:      expandBucketCORSRules(d.Get("cors_rule").([]interface{})),
+		}
+
+		_, err := c.UpdateBucket(ctx, d.Id(), req)
+		if err != nil {
+			if helpers.IsNotFoundError(err) {
+				d.SetId("")
+				return nil
+			}
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceAceCloudObjectStorageBucketRead(ctx, d, meta)
+}
+
+func resourceAceCloudObjectStorageBucketDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := helpers.ScopedClient(d, meta)
+
+	err := c.DeleteBucket(ctx, d.Id(), d.Get("force_destroy").(bool))
+	if err != nil {
+		if helpers.IsNotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// resourceAceCloudObjectStorageBucketImport accepts a bucket name, optionally
+// prefixed with "<region>/<project_id>/".
+func resourceAceCloudObjectStorageBucketImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	imported, err := helpers.ImportStateScoped(1)(ctx, d, meta)
+	if err != nil {
+		return nil, err
+	}
+	// force_destroy only exists in configuration; default it so that an
+	// imported bucket does not show a spurious diff.
+	_ = d.Set("force_destroy", false)
+	return imported, nil
+}
+
+func expandBucketLifecycleRules(raw []interface{}) []types.BucketLifecycleRule {
+	rules := make([]types.BucketLifecycleRule, 0, len(raw))
+	for _, it := range raw {
+		m := it.(map[string]interface{})
+		rules = append(rules, types.BucketLifecycleRule{
+			ID:                                 m["id"].(string),
+			Enabled:                            m["enabled"].(bool),
+			Prefix:                             m["prefix"].(string),
+			ExpirationDays:                     m["expiration_days"].(int),
+			NoncurrentVersionExpirationDays:    m["noncurrent_version_expiration_days"].(int),
+			AbortIncompleteMultipartUploadDays: m["abort_incomplete_multipart_upload_days"].(int),
+		})
+	}
+	return rules
+}
+
+func flattenBucketLifecycleRules(rules []types.BucketLifecycleRule) []interface{} {
+	out := make([]interface{}, 0, len(rules))
+	for _, r := range rules {
+		out = append(out, map[string]interface{}{
+			"id":                                     r.ID,
+			"enabled":                                r.Enabled,
+			"prefix":                                 r.Prefix,
+			"expiration_days":                        r.ExpirationDays,
+			"noncurrent_version_expiration_days":     r.NoncurrentVersionExpirationDays,
+			"abort_incomplete_multipart_upload_days": r.AbortIncompleteMultipartUploadDays,
+		})
+	}
+	return out
+}
+
+func expandBucketCORSRules(raw []interface{}) []types.BucketCORSRule {
+	rules := make([]types.BucketCORSRule, 0, len(raw))
+	for _, it := range raw {
+		m := it.(map[string]interface{})
+		rules = append(rules, types.BucketCORSRule{
+			AllowedOrigins: helpers.InterfaceSliceToStringSlice(m["allowed_origins"].([]interface{})),
+			AllowedMethods: helpers.InterfaceSliceToStringSlice(m["allowed_methods"].([]interface{})),
+			AllowedHeaders: helpers.InterfaceSliceToStringSlice(m["allowed_headers"].([]interface{})),
+			ExposeHeaders:  helpers.InterfaceSliceToStringSlice(m["expose_headers"].([]interface{})),
+			MaxAgeSeconds:  m["max_age_seconds"].(int),
+		})
+	}
+	return rules
+}
+
+func flattenBucketCORSRules(rules []types.BucketCORSRule) []interface{} {
+	out := make([]interface{}, 0, len(rules))
+	for _, r := range rules {
+		out = append(out, map[string]interface{}{
+			"allowed_origins": r.AllowedOrigins,
+			"allowed_methods": r.AllowedMethods,
+			"allowed_headers": r.AllowedHeaders,
+			"expose_headers":  r.ExposeHeaders,
+			"max_age_seconds": r.MaxAgeSeconds,
+		})
+	}
+	return out
+}
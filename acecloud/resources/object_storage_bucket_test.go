@@ -0,0 +1,181 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+const testBucket = `{"data":{
+	"name": "assets",
+	"versioning": true,
+	"acl": "public-read",
+	"public_access": true,
+	"lifecycle_rules": [{"id": "expire-logs", "enabled": true, "prefix": "logs/", "expiration_days": 30}],
+	"cors_rules": [{"allowed_origins": ["https://example.com"], "allowed_methods": ["GET", "HEAD"], "max_age_seconds": 600}],
+	"endpoint": "https://assets.s3.test-region.acecloud.test",
+	"created_at": "2026-01-01T00:00:00Z"
+}}`
+
+var testBucketConfig = map[string]interface{}{
+	"name":          "assets",
+	"versioning":    true,
+	"acl":           "public-read",
+	"public_access": true,
+	"lifecycle_rule": []interface{}{map[string]interface{}{
+		"id":              "expire-logs",
+		"prefix":          "logs/",
+		"expiration_days": 30,
+	}},
+	"cors_rule": []interface{}{map[string]interface{}{
+		"allowed_origins": []interface{}{"https://example.com"},
+		"allowed_methods": []interface{}{"GET", "HEAD"},
+		"max_age_seconds": 600,
+	}},
+}
+
+func TestObjectStorageBucketCreate(t *testing.T) {
+	api := newStubAPI()
+	api.handle("POST /object-storage/buckets", http.StatusOK, `{"data":{"name":"assets"}}`)
+	api.handle("GET /object-storage/buckets/assets", http.StatusOK, testBucket)
+	r := ResourceAceCloudObjectStorageBucket()
+	d := resourceData(t, r, "", testBucketConfig)
+
+	if diags := r.CreateContext(context.Background(), d, api.client()); diags.HasError() {
+		t.Fatalf("create: %v", diags)
+	}
+	wantRoutes(t, api,
+		"POST /object-storage/buckets test-region",
+		"GET /object-storage/buckets/assets test-region",
+	)
+
+	var body map[string]interface{}
+	if err := json.Unmarshal([]byte(api.bodies[0]), &body); err != nil {
+		t.Fatal(err)
+	}
+	want := `{"acl":"public-read",` +
+		`"cors_rules":[{"allowed_methods":["GET","HEAD"],"allowed_origins":["https://example.com"],"max_age_seconds":600}],` +
+		`"lifecycle_rules":[{"enabled":true,"expiration_days":30,"id":"expire-logs","prefix":"logs/"}],` +
+		`"name":"assets","public_access":true,"versioning":true}`
+	if got, _ := json.Marshal(body); string(got) != want {
+		t.Errorf("create request body:\n%s\nwant:\n%s", got, want)
+	}
+
+	if d.Id() != "assets" || d.Get("endpoint") != "https://assets.s3.test-region.acecloud.test" || d.Get("region") != "test-region" {
+		t.Errorf("created %q with endpoint %q in %q", d.Id(), d.Get("endpoint"), d.Get("region"))
+	}
+	if d.Get("lifecycle_rule.0.expiration_days") != 30 || d.Get("cors_rule.0.allowed_methods.1") != "HEAD" {
+		t.Errorf("rules = %v, %v", d.Get("lifecycle_rule"), d.Get("cors_rule"))
+	}
+}
+
+// An imported bucket matches its configuration without a diff, even though
+// force_destroy is never read from the API.
+func TestObjectStorageBucketImport(t *testing.T) {
+	api := newStubAPI()
+	api.handle("GET /object-storage/buckets/assets", http.StatusOK, testBucket)
+	r := ResourceAceCloudObjectStorageBucket()
+
+	d, diags := importState(t, r, "assets", api.client())
+	if diags.HasError() {
+		t.Fatalf("import: %v", diags)
+	}
+	if d.Get("force_destroy") != false || d.Get("acl") != "public-read" {
+		t.Errorf("imported force_destroy %v, acl %q", d.Get("force_destroy"), d.Get("acl"))
+	}
+
+	diff, err := r.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(testBucketConfig), api.client())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !diff.Empty() {
+		t.Errorf("diff after import = %v, want none", diffAttributes(diff))
+	}
+}
+
+// An import ID prefixed with region and project_id reads the bucket there.
+func TestObjectStorageBucketImportScoped(t *testing.T) {
+	api := newStubAPI()
+	api.handle("GET /object-storage/buckets/assets", http.StatusOK, testBucket)
+	r := ResourceAceCloudObjectStorageBucket()
+
+	d, diags := importState(t, r, "other-region/other-project/assets", api.client())
+	if diags.HasError() {
+		t.Fatalf("import: %v", diags)
+	}
+	wantRoutes(t, api, "GET /object-storage/buckets/assets other-region")
+	if d.Id() != "assets" || d.Get("region") != "other-region" || d.Get("project_id") != "other-project" || d.Get("force_destroy") != false {
+		t.Errorf("imported ID %q, region %q, project_id %q, force_destroy %v", d.Id(), d.Get("region"), d.Get("project_id"), d.Get("force_destroy"))
+	}
+}
+
+func TestObjectStorageBucketDelete(t *testing.T) {
+	for _, force := range []bool{false, true} {
+		api := newStubAPI()
+		api.handle("DELETE /object-storage/buckets/assets", http.StatusOK, `{"data":{}}`)
+		r := ResourceAceCloudObjectStorageBucket()
+		d := resourceData(t, r, "assets", map[string]interface{}{"name": "assets", "force_destroy": force})
+
+		if diags := r.DeleteContext(context.Background(), d, api.client()); diags.HasError() {
+			t.Fatalf("delete: %v", diags)
+		}
+		wantRoutes(t, api, "DELETE /object-storage/buckets/assets test-region")
+		if got := api.requests[0].URL.Query().Has("force"); got != force {
+			t.Errorf("delete with force_destroy %t sent force %t", force, got)
+		}
+	}
+}
+
+func TestObjectStorageBucketReadGone(t *testing.T) {
+	api := newStubAPI()
+	r := ResourceAceCloudObjectStorageBucket()
+	d := resourceData(t, r, "assets", map[string]interface{}{"name": "assets"})
+
+	if diags := r.ReadContext(context.Background(), d, api.client()); diags.HasError() {
+		t.Fatalf("read: %v", diags)
+	}
+	if d.Id() != "" {
+		t.Errorf("ID = %q after the bucket disappeared, want it removed from state", d.Id())
+	}
+}
+
+// The secret key is only returned when the credentials are created, and
+// reads must keep it.
+func TestObjectStorageCredentials(t *testing.T) {
+	api := newStubAPI()
+	api.handle("POST /object-storage/credentials", http.StatusOK, `{"data":{"id":"cred-1","access_key":"AK1","secret_key":"SK1"}}`)
+	api.handle("GET /object-storage/credentials/cred-1", http.StatusOK, `{"data":{"id":"cred-1","description":"ci","access_key":"AK1","s3_endpoint":"https://s3.test-region.acecloud.test"}}`)
+	api.handle("DELETE /object-storage/credentials/cred-1", http.StatusOK, `{"data":{}}`)
+	r := ResourceAceCloudObjectStorageCredentials()
+	d := resourceData(t, r, "", map[string]interface{}{"description": "ci"})
+
+	if diags := r.CreateContext(context.Background(), d, api.client()); diags.HasError() {
+		t.Fatalf("create: %v", diags)
+	}
+	if api.bodies[0] != `{"description":"ci"}` {
+		t.Errorf("create request body = %s", api.bodies[0])
+	}
+	if d.Id() != "cred-1" || d.Get("access_key") != "AK1" || d.Get("secret_key") != "SK1" || d.Get("s3_endpoint") != "https://s3.test-region.acecloud.test" {
+		t.Errorf("created %q: access_key %q, secret_key %q, s3_endpoint %q", d.Id(), d.Get("access_key"), d.Get("secret_key"), d.Get("s3_endpoint"))
+	}
+
+	if diags := r.ReadContext(context.Background(), d, api.client()); diags.HasError() {
+		t.Fatalf("read: %v", diags)
+	}
+	if d.Get("secret_key") != "SK1" {
+		t.Errorf("secret_key = %q after read", d.Get("secret_key"))
+	}
+
+	if diags := r.DeleteContext(context.Background(), d, api.client()); diags.HasError() {
+		t.Fatalf("delete: %v", diags)
+	}
+	wantRoutes(t, api,
+		"POST /object-storage/credentials test-region",
+		"GET /object-storage/credentials/cred-1 test-region",
+		"GET /object-storage/credentials/cred-1 test-region",
+		"DELETE /object-storage/credentials/cred-1 test-region",
+	)
+}
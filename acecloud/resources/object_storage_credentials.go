@@ -0,0 +1,110 @@
+package resources
+
+import (
+	"context"
+
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/client/types"
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/helpers"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ResourceAceCloudObjectStorageCredentials manages an S3-compatible access key
+// pair. The secret key is only returned on creation, so the resource cannot be
+// imported and any change replaces the key pair. It is kept in state, in plain
+// text, as the only place it can be read from again.
+func ResourceAceCloudObjectStorageCredentials() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceAceCloudObjectStorageCredentialsCreate,
+		ReadContext:   resourceAceCloudObjectStorageCredentialsRead,
+		DeleteContext: resourceAceCloudObjectStorageCredentialsDelete,
+
+		Schema: map[string]*schema.Schema{
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Free-form description of what the credentials are used for",
+			},
+			"access_key": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "S3-compatible access key ID",
+			},
+			"secret_key": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "S3-compatible secret access key. It is stored in plain text in the Terraform state, so the state must be protected like the key itself",
+			},
+			"s3_endpoint": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "S3-compatible endpoint URL to use with the credentials",
+			},
+			"created_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Creation timestamp of the credentials",
+			},
+			"region":     helpers.RegionSchema(),
+			"project_id": helpers.ProjectIDSchema(),
+		},
+	}
+}
+
+func resourceAceCloudObjectStorageCredentialsCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := helpers.ScopedClient(d, meta)
+
+	req := &types.ObjectStorageCredentialsCreateRequest{
+		Description: d.Get("description").(string),
+	}
+
+	resp, err := c.CreateObjectStorageCredentials(ctx, req)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(resp.ID)
+	_ = d.Set("secret_key", resp.SecretKey)
+
+	return resourceAceCloudObjectStorageCredentialsRead(ctx, d, meta)
+}
+
+func resourceAceCloudObjectStorageCredentialsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := helpers.ScopedClient(d, meta)
+
+	resp, err := c.GetObjectStorageCredentials(ctx, d.Id())
+	if err != nil {
+		if helpers.IsNotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	_ = d.Set("description", resp.Description)
+	_ = d.Set("access_key", resp.AccessKey)
+	_ = d.Set("s3_endpoint", resp.S3Endpoint)
+	_ = d.Set("created_at", resp.CreatedAt)
+
+	helpers.SetScope(d, c)
+
+	return nil
+}
+
+func resourceAceCloudObjectStorageCredentialsDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := helpers.ScopedClient(d, meta)
+
+	err := c.DeleteObjectStorageCredentials(ctx, d.Id())
+	if err != nil {
+		if helpers.IsNotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,50 @@
+package resources_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"testing"
+
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/internal/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestMain(m *testing.M) {
+	resource.TestMain(m)
+}
+
+func init() {
+	resource.AddTestSweepers("acecloud_vm", &resource.Sweeper{
+		Name: "acecloud_vm",
+		F:    sweepVMs,
+	})
+}
+
+// sweepVMs deletes the VMs left behind by acceptance tests in one request.
+func sweepVMs(region string) error {
+	c, err := acctest.SweeperClient(region)
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	var ids []string
+	for vm, err := range c.VMs(ctx, nil) {
+		if err != nil {
+			return fmt.Errorf("error listing VMs: %w", err)
+		}
+		if acctest.IsSweepable(vm.Name) {
+			ids = append(ids, vm.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	log.Printf("[INFO] Sweeping %d VMs in %s", len(ids), region)
+	if err := c.DeleteVMs(ctx, ids); err != nil {
+		return fmt.Errorf("error deleting VMs: %w", err)
+	}
+	return nil
+}
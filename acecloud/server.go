@@ -0,0 +1,94 @@
+package acecloud
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud/fwresources"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-mux/tf5to6server"
+	"github.com/hashicorp/terraform-plugin-mux/tf6muxserver"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// FrameworkVMEnvVar opts into the terraform-plugin-framework implementation of
+// acecloud_vm. The two implementations share a schema and state format, so
+// the variable can be set and unset between runs without touching state.
+const FrameworkVMEnvVar = "ACECLOUD_FRAMEWORK_VM"
+
+// ProtoV6ProviderServerFactory returns a protocol v6 server that muxes the SDK
+// provider, upgraded from protocol v5, with the framework provider. The SDK
+// provider comes first so that it is configured before the framework provider
+// reads its client.
+func ProtoV6ProviderServerFactory(ctx context.Context, version string) (func() tfprotov6.ProviderServer, error) {
+	return ProtoV6ProviderServerFactoryFor(ctx, Provider(version), version)
+}
+
+// ProtoV6ProviderServerFactoryFor is ProtoV6ProviderServerFactory for an SDK
+// provider returned by Provider, which callers such as the acceptance tests
+// may adjust first.
+func ProtoV6ProviderServerFactoryFor(ctx context.Context, sdkProvider *schema.Provider, version string) (func() tfprotov6.ProviderServer, error) {
+	fwProvider := &frameworkProvider{
+		sdkProvider: sdkProvider,
+		version:     version,
+	}
+
+	frameworkVM := os.Getenv(FrameworkVMEnvVar) != ""
+	if frameworkVM {
+		delete(sdkProvider.ResourcesMap, "acecloud_vm")
+		fwProvider.resources = append(fwProvider.resources, fwresources.NewVMResource)
+	}
+
+	upgradedSDKServer, err := tf5to6server.UpgradeServer(ctx, sdkProvider.GRPCProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	if frameworkVM {
+		fwProvider.listResources = append(fwProvider.listResources, fwresources.NewVMListResource(nil, nil))
+	} else {
+		resourceSchema, identitySchema, err := sdkResourceSchemas(ctx, upgradedSDKServer, "acecloud_vm")
+		if err != nil {
+			return nil, err
+		}
+		fwProvider.listResources = append(fwProvider.listResources, fwresources.NewVMListResource(resourceSchema, identitySchema))
+	}
+
+	servers := []func() tfprotov6.ProviderServer{
+		func() tfprotov6.ProviderServer { return upgradedSDKServer },
+		providerserver.NewProtocol6(fwProvider),
+	}
+
+	muxServer, err := tf6muxserver.NewMuxServer(ctx, servers...)
+	if err != nil {
+		return nil, err
+	}
+
+	return muxServer.ProviderServer, nil
+}
+
+// sdkResourceSchemas returns the protocol v6 schema and identity schema of an
+// SDK resource, which framework list resources need to list it.
+func sdkResourceSchemas(ctx context.Context, server tfprotov6.ProviderServer, typeName string) (*tfprotov6.Schema, *tfprotov6.ResourceIdentitySchema, error) {
+	schemas, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		return nil, nil, err
+	}
+	identities, err := server.GetResourceIdentitySchemas(ctx, &tfprotov6.GetResourceIdentitySchemasRequest{})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resourceSchema, ok := schemas.ResourceSchemas[typeName]
+	if !ok {
+		return nil, nil, fmt.Errorf("SDK provider has no schema for %s", typeName)
+	}
+	identitySchema, ok := identities.IdentitySchemas[typeName]
+	if !ok {
+		return nil, nil, fmt.Errorf("SDK provider has no identity schema for %s", typeName)
+	}
+
+	return resourceSchema, identitySchema, nil
+}
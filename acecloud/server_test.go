@@ -0,0 +1,57 @@
+package acecloud
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+// providerSchema returns the schema served by the muxed provider, with
+// acecloud_vm served by the framework when frameworkVM is set.
+func providerSchema(t *testing.T, frameworkVM bool) *tfprotov6.GetProviderSchemaResponse {
+	t.Helper()
+	t.Setenv(FrameworkVMEnvVar, "")
+	if frameworkVM {
+		t.Setenv(FrameworkVMEnvVar, "1")
+	}
+
+	ctx := context.Background()
+	factory, err := ProtoV6ProviderServerFactory(ctx, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := factory().GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range resp.Diagnostics {
+		if d.Severity == tfprotov6.DiagnosticSeverityError {
+			t.Fatalf("GetProviderSchema: %s: %s", d.Summary, d.Detail)
+		}
+	}
+	return resp
+}
+
+func TestProviderServerSchema(t *testing.T) {
+	sdk := providerSchema(t, false)
+	fw := providerSchema(t, true)
+
+	for name := range Provider("test").ResourcesMap {
+		if sdk.ResourceSchemas[name] == nil || fw.ResourceSchemas[name] == nil {
+			t.Errorf("resource %s is not served", name)
+		}
+	}
+	for name := range Provider("test").DataSourcesMap {
+		if sdk.DataSourceSchemas[name] == nil {
+			t.Errorf("data source %s is not served", name)
+		}
+	}
+
+	// Switching implementations must not change the state format.
+	sdkVM := sdk.ResourceSchemas["acecloud_vm"].ValueType()
+	fwVM := fw.ResourceSchemas["acecloud_vm"].ValueType()
+	if !sdkVM.Equal(fwVM) {
+		t.Errorf("framework acecloud_vm type = %s, want %s", fwVM, sdkVM)
+	}
+}
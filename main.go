@@ -1,13 +1,19 @@
 package main
 
 import (
+	"context"
 	"flag"
+	"log"
+
 	"github.com/AceCloudAI/terraform-provider-acecloud/acecloud"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6/tf6server"
 )
 
 const providerAddr = "registry.terraform.io/acecloud/acecloud"
 
+// version is set by the release build with -ldflags "-X main.version=...".
+var version = "dev"
+
 func main() {
 
 	var debugMode bool
@@ -15,9 +21,20 @@ func main() {
 	flag.BoolVar(&debugMode, "debug", false, "set to true to run the provider with support for debuggers like delve")
 	flag.Parse()
 
-	plugin.Serve(&plugin.ServeOpts{
-		Debug:        debugMode,
-		ProviderAddr: providerAddr,
-		ProviderFunc: acecloud.Provider,
-	})
-}
\ No newline at end of file
+	ctx := context.Background()
+
+	serverFactory, err := acecloud.ProtoV6ProviderServerFactory(ctx, version)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var serveOpts []tf6server.ServeOpt
+	if debugMode {
+		serveOpts = append(serveOpts, tf6server.WithManagedDebug())
+	}
+
+	err = tf6server.Serve(providerAddr, serverFactory, serveOpts...)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -8,9 +8,9 @@ terraform {
 }
 
 
+# The API key is read from ACECLOUD_API_KEY.
 provider "acecloud" {
   api_endpoint = "http://localhost:3001"
-  api_key      = "7c57b3ec-58a6-4428-b2c6-e9a6ce330682.f5e83544566d861f879b03e1f8b6ca894cc50c9c8636a6e7079fa7bc0d9efddf" 
   region       = "ap-south-mum-1"
   project_id   = "251b42b560eb415db84cdc285fb125f4"
 }